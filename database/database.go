@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"task-api/models"
+	"task-api/repositories"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -54,11 +55,35 @@ func Connect() error {
 	DB = db
 	log.Println("Database connected successfully")
 
-	if err := db.AutoMigrate(&models.User{}, &models.Task{}); err != nil {
+	emailVerificationExisted := db.Migrator().HasColumn(&models.User{}, "email_verified_at")
+
+	if err := db.AutoMigrate(&models.User{}, &models.Workspace{}, &models.WorkspaceMember{}, &models.WorkspaceInvitation{}, &models.WorkflowStatus{}, &models.Project{}, &models.Task{}, &models.TaskShare{}, &models.TaskDependency{}, &models.Reminder{}, &models.Notification{}, &models.Comment{}, &models.Attachment{}, &models.TaskActivity{}, &models.Label{}, &models.RefreshToken{}, &models.Session{}, &models.OneTimeToken{}, &models.RecoveryCode{}, &models.PersonalAccessToken{}, &models.LoginAttempt{}); err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 	log.Println("Database migration completed successfully")
 
+	if !emailVerificationExisted {
+		if err := backfillEmailVerification(db); err != nil {
+			return fmt.Errorf("failed to backfill email verification: %w", err)
+		}
+	}
+
+	if err := encryptTOTPSecrets(db); err != nil {
+		return fmt.Errorf("failed to encrypt TOTP secrets: %w", err)
+	}
+
+	if err := backfillWorkspaces(db); err != nil {
+		return fmt.Errorf("failed to backfill workspaces: %w", err)
+	}
+
+	if err := backfillStatusCategories(db); err != nil {
+		return fmt.Errorf("failed to backfill task status categories: %w", err)
+	}
+
+	if err := db.Use(repositories.WorkspaceScopePlugin{}); err != nil {
+		return fmt.Errorf("failed to register workspace scoping: %w", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"task-api/models"
+	"task-api/utils"
+
+	"gorm.io/gorm"
+)
+
+// encryptTOTPSecrets encrypts TOTP secrets that were stored before secrets
+// were encrypted at rest.
+func encryptTOTPSecrets(db *gorm.DB) error {
+	var users []models.User
+	err := db.Unscoped().Select("id", "totp_secret").
+		Where("totp_secret <> '' AND totp_secret NOT LIKE ?", utils.EncryptedSecretPrefix+"%").
+		Find(&users).Error
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		encrypted, err := utils.EncryptSecret(user.TOTPSecret)
+		if err != nil {
+			return err
+		}
+		if err := db.Unscoped().Model(&models.User{}).Where("id = ?", user.ID).UpdateColumn("totp_secret", encrypted).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,12 @@
+package database
+
+import (
+	"gorm.io/gorm"
+)
+
+// backfillEmailVerification marks accounts that predate email verification as
+// verified, so that a stricter EMAIL_VERIFICATION_POLICY does not lock them
+// out. It only runs in the migration that adds email_verified_at.
+func backfillEmailVerification(db *gorm.DB) error {
+	return db.Exec("UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL").Error
+}
\ No newline at end of file
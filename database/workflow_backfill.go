@@ -0,0 +1,14 @@
+package database
+
+import (
+	"gorm.io/gorm"
+)
+
+// backfillStatusCategories sets the category of tasks whose built-in status
+// predates status categories. Workspaces with a custom workflow already keep
+// their tasks' categories up to date and are left alone.
+func backfillStatusCategories(db *gorm.DB) error {
+	return db.Exec(`UPDATE tasks SET status_category = CASE tasks.status WHEN 'in_progress' THEN 'in_progress' WHEN 'completed' THEN 'done' ELSE 'cancelled' END
+	WHERE tasks.status IN ('in_progress', 'completed', 'cancelled') AND tasks.status_category = 'todo'
+	AND NOT EXISTS (SELECT 1 FROM workflow_statuses WHERE workflow_statuses.workspace_id = tasks.workspace_id)`).Error
+}
\ No newline at end of file
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"gorm.io/gorm"
+)
+
+var workspaceBackfillStatements = []string{
+	`INSERT INTO workspaces (created_at, updated_at, name, personal, owner_id)
+	SELECT NOW(), NOW(), 'Personal', TRUE, users.id FROM users
+	WHERE NOT EXISTS (SELECT 1 FROM workspaces WHERE workspaces.owner_id = users.id AND workspaces.personal)`,
+	`INSERT INTO workspace_members (created_at, updated_at, workspace_id, user_id, role)
+	SELECT NOW(), NOW(), workspaces.id, workspaces.owner_id, 'owner' FROM workspaces
+	WHERE workspaces.personal AND workspaces.deleted_at IS NULL
+	ON CONFLICT (workspace_id, user_id) DO NOTHING`,
+	`UPDATE tasks SET workspace_id = workspaces.id FROM workspaces
+	WHERE workspaces.personal AND workspaces.owner_id = tasks.user_id AND (tasks.workspace_id IS NULL OR tasks.workspace_id = 0)`,
+	`UPDATE projects SET workspace_id = workspaces.id FROM workspaces
+	WHERE workspaces.personal AND workspaces.owner_id = projects.user_id AND (projects.workspace_id IS NULL OR projects.workspace_id = 0)`,
+	`INSERT INTO workspace_members (created_at, updated_at, workspace_id, user_id, role)
+	SELECT DISTINCT NOW(), NOW(), tasks.workspace_id, task_shares.user_id, 'guest' FROM task_shares
+	JOIN tasks ON tasks.id = task_shares.task_id
+	ON CONFLICT (workspace_id, user_id) DO NOTHING`,
+	`INSERT INTO workspace_members (created_at, updated_at, workspace_id, user_id, role)
+	SELECT DISTINCT NOW(), NOW(), tasks.workspace_id, tasks.assignee_id, 'guest' FROM tasks
+	WHERE tasks.assignee_id IS NOT NULL
+	ON CONFLICT (workspace_id, user_id) DO NOTHING`,
+}
+
+// backfillWorkspaces gives every user a personal workspace and moves tasks and
+// projects created before workspaces existed into their owner's one. Users who
+// had been given access to such tasks join that workspace as guests.
+func backfillWorkspaces(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range workspaceBackfillStatements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
\ No newline at end of file
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"task-api/middleware"
+	"task-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const multipartOverhead = 1 << 20
+
+type AttachmentHandler struct {
+	attachmentService services.AttachmentService
+	maxFileSize       int64
+}
+
+func NewAttachmentHandler(attachmentService services.AttachmentService, maxFileSize int64) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		maxFileSize:       maxFileSize,
+	}
+}
+
+func (h *AttachmentHandler) ListAttachments(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	result, err := h.service(c).ListAttachments(userID, taskID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get attachments")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Attachments retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxFileSize+multipartOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.handleServiceError(c, services.ErrAttachmentTooLarge, "Attachment upload failed")
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "A multipart file field named file is required",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.handleServiceError(c, err, "Attachment upload failed")
+		return
+	}
+	defer file.Close()
+
+	result, err := h.service(c).UploadAttachment(userID, taskID, services.AttachmentUpload{
+		Filename: fileHeader.Filename,
+		Size:     fileHeader.Size,
+		Content:  file,
+	})
+	if err != nil {
+		h.handleServiceError(c, err, "Attachment upload failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Attachment uploaded successfully",
+		"data":    result,
+	})
+}
+
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, attachmentID, ok := h.getIDParams(c)
+	if !ok {
+		return
+	}
+
+	attachment, content, err := h.service(c).OpenAttachment(userID, taskID, attachmentID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to download attachment")
+		return
+	}
+	defer content.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    disposition,
+		"X-Content-Type-Options": "nosniff",
+		"ETag":                   `"` + attachment.SHA256 + `"`,
+	})
+}
+
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, attachmentID, ok := h.getIDParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service(c).DeleteAttachment(userID, taskID, attachmentID); err != nil {
+		h.handleServiceError(c, err, "Attachment deletion failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Attachment deleted successfully",
+	})
+}
+
+func (h *AttachmentHandler) getIDParams(c *gin.Context) (uint, uint, bool) {
+	taskID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return 0, 0, false
+	}
+
+	attachmentID, err := getIDParam(c, "attachmentId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_attachment_id",
+			"message": "Invalid attachment ID",
+		})
+		return 0, 0, false
+	}
+
+	return taskID, attachmentID, true
+}
+
+func (h *AttachmentHandler) service(c *gin.Context) services.AttachmentService {
+	return h.attachmentService.InWorkspace(middleware.GetWorkspaceID(c))
+}
+
+func (h *AttachmentHandler) handleServiceError(c *gin.Context, err error, defaultMessage string) {
+	statusCode := http.StatusInternalServerError
+	errorType := "internal_error"
+	message := defaultMessage
+
+	switch err {
+	case services.ErrTaskNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "task_not_found"
+		message = "Task not found"
+	case services.ErrUnauthorizedAccess:
+		statusCode = http.StatusForbidden
+		errorType = "unauthorized_access"
+		message = "You don't have permission to access this task"
+	case services.ErrAttachmentNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "attachment_not_found"
+		message = "Attachment not found"
+	case services.ErrAttachmentTooLarge:
+		statusCode = http.StatusRequestEntityTooLarge
+		errorType = "attachment_too_large"
+		message = "The file exceeds the maximum attachment size"
+	case services.ErrAttachmentQuotaExceeded:
+		statusCode = http.StatusForbidden
+		errorType = "storage_quota_exceeded"
+		message = "This upload would exceed your attachment storage quota"
+	case services.ErrUnsupportedContentType:
+		statusCode = http.StatusUnsupportedMediaType
+		errorType = "unsupported_content_type"
+		message = "This file type is not allowed"
+	default:
+		if validationErr, ok := err.(services.ValidationErrors); ok {
+			statusCode = http.StatusBadRequest
+			errorType = "validation_error"
+			message = "Validation failed"
+			c.JSON(statusCode, gin.H{
+				"error":   errorType,
+				"message": message,
+				"details": validationErr.Errors,
+			})
+			return
+		}
+	}
+
+	c.JSON(statusCode, gin.H{
+		"error":   errorType,
+		"message": message,
+	})
+}
\ No newline at end of file
@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"task-api/middleware"
 	"task-api/services"
@@ -30,7 +33,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	result, err := h.authService.Register(dto)
+	result, err := h.authService.Register(dto, clientInfo(c))
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 		errorType := "internal_error"
@@ -83,8 +86,12 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	result, err := h.authService.Login(dto)
+	result, err := h.authService.Login(dto, clientInfo(c))
 	if err != nil {
+		if h.handleThrottleError(c, err) {
+			return
+		}
+
 		statusCode := http.StatusInternalServerError
 		errorType := "internal_error"
 		message := "Login failed"
@@ -98,6 +105,10 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			statusCode = http.StatusForbidden
 			errorType = "account_inactive"
 			message = "Account is inactive"
+		case services.ErrEmailNotVerified:
+			statusCode = http.StatusForbidden
+			errorType = "email_not_verified"
+			message = "Please verify your email address before logging in"
 		default:
 			if validationErr, ok := err.(services.ValidationErrors); ok {
 				statusCode = http.StatusBadRequest
@@ -119,6 +130,14 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	if result.MFARequired {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Two-factor authentication required",
+			"data":    result,
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Login successful",
 		"data":    result,
@@ -136,13 +155,18 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	result, err := h.authService.RefreshToken(dto)
+	result, err := h.authService.RefreshToken(dto, clientInfo(c))
 	if err != nil {
 		statusCode := http.StatusUnauthorized
 		errorType := "invalid_token"
 		message := "Token refresh failed"
 
 		switch err {
+		case services.ErrInvalidRefreshToken:
+			message = "Refresh token is invalid or expired"
+		case services.ErrRefreshTokenReused:
+			errorType = "token_reused"
+			message = "Refresh token has already been used; all sessions in this chain were revoked"
 		case services.ErrUserNotFound:
 			statusCode = http.StatusNotFound
 			errorType = "user_not_found"
@@ -166,6 +190,190 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	})
 }
 
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var dto services.LogoutDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.authService.Logout(dto); err != nil {
+		statusCode := http.StatusInternalServerError
+		errorType := "internal_error"
+		message := "Logout failed"
+
+		if err == services.ErrInvalidRefreshToken {
+			statusCode = http.StatusUnauthorized
+			errorType = "invalid_token"
+			message = "Refresh token is invalid"
+		}
+
+		c.JSON(statusCode, gin.H{
+			"error":   errorType,
+			"message": message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logout successful",
+	})
+}
+
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	if err := h.authService.LogoutAll(userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Logout failed",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logged out of all sessions",
+	})
+}
+
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var dto services.ForgotPasswordDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.authService.ForgotPassword(dto); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Password reset request failed",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "If an account exists for that email, a password reset link has been sent",
+	})
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var dto services.ResetPasswordDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.authService.ResetPassword(dto); err != nil {
+		statusCode := http.StatusInternalServerError
+		errorType := "internal_error"
+		message := "Password reset failed"
+
+		switch err {
+		case services.ErrInvalidResetToken:
+			statusCode = http.StatusBadRequest
+			errorType = "invalid_reset_token"
+			message = "Password reset token is invalid or has expired"
+		default:
+			if validationErr, ok := err.(services.ValidationErrors); ok {
+				statusCode = http.StatusBadRequest
+				errorType = "validation_error"
+				message = "Validation failed"
+				c.JSON(statusCode, gin.H{
+					"error":   errorType,
+					"message": message,
+					"details": validationErr.Errors,
+				})
+				return
+			}
+		}
+
+		c.JSON(statusCode, gin.H{
+			"error":   errorType,
+			"message": message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Password has been reset; please log in again",
+	})
+}
+
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var dto services.VerifyEmailDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.authService.VerifyEmail(dto)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		errorType := "internal_error"
+		message := "Email verification failed"
+
+		if err == services.ErrInvalidVerificationToken {
+			statusCode = http.StatusBadRequest
+			errorType = "invalid_verification_token"
+			message = "Verification token is invalid or has expired"
+		}
+
+		c.JSON(statusCode, gin.H{
+			"error":   errorType,
+			"message": message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email verified successfully",
+		"data":    result,
+	})
+}
+
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var dto services.ResendVerificationDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.authService.ResendVerification(dto); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to resend verification email",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "If the account exists and is unverified, a verification email has been sent",
+	})
+}
+
 func (h *AuthHandler) GetProfile(c *gin.Context) {
 	userID := middleware.RequireUserID(c)
 	if userID == 0 {
@@ -195,4 +403,310 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 		"message": "Profile retrieved successfully",
 		"data":    result,
 	})
+}
+
+func (h *AuthHandler) UpdateProfile(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	var dto services.UpdateProfileDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.authService.UpdateProfile(userID, dto)
+	if err != nil {
+		h.handleAccountError(c, err, "Failed to update profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Profile updated successfully",
+		"data":    result,
+	})
+}
+
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	var dto services.ChangePasswordDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	sessionID := ""
+	if claims, ok := middleware.GetUserClaims(c); ok {
+		sessionID = claims.SessionID
+	}
+
+	if err := h.authService.ChangePassword(userID, sessionID, dto); err != nil {
+		if h.handleThrottleError(c, err) {
+			return
+		}
+		h.handleAccountError(c, err, "Failed to change password")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Password changed successfully; other sessions have been signed out",
+	})
+}
+
+func (h *AuthHandler) EnrollTOTP(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	result, err := h.authService.EnrollTOTP(userID)
+	if err != nil {
+		h.handleAccountError(c, err, "Two-factor enrollment failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Scan the otpauth URI with your authenticator app and confirm with a code",
+		"data":    result,
+	})
+}
+
+func (h *AuthHandler) ConfirmTOTP(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	var dto services.TOTPCodeDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.authService.ConfirmTOTP(userID, dto)
+	if err != nil {
+		h.handleAccountError(c, err, "Two-factor confirmation failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Two-factor authentication enabled; store these recovery codes somewhere safe",
+		"data":    result,
+	})
+}
+
+func (h *AuthHandler) DisableTOTP(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	var dto services.DisableTOTPDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.authService.DisableTOTP(userID, dto); err != nil {
+		h.handleAccountError(c, err, "Failed to disable two-factor authentication")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Two-factor authentication disabled",
+	})
+}
+
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var dto services.VerifyMFADTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.authService.VerifyMFA(dto, clientInfo(c))
+	if err != nil {
+		if h.handleThrottleError(c, err) {
+			return
+		}
+
+		h.handleAccountError(c, err, "Two-factor verification failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Login successful",
+		"data":    result,
+	})
+}
+
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	currentSessionID := ""
+	if claims, ok := middleware.GetUserClaims(c); ok {
+		currentSessionID = claims.SessionID
+	}
+
+	result, err := h.authService.ListSessions(userID, currentSessionID)
+	if err != nil {
+		h.handleAccountError(c, err, "Failed to get sessions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sessions retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	sessionID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_session_id",
+			"message": "Invalid session ID",
+		})
+		return
+	}
+
+	if err := h.authService.RevokeSession(userID, sessionID); err != nil {
+		h.handleAccountError(c, err, "Failed to revoke session")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Session revoked successfully",
+	})
+}
+
+func (h *AuthHandler) handleThrottleError(c *gin.Context, err error) bool {
+	switch e := err.(type) {
+	case services.AccountLockedError:
+		retryAfter := retryAfterSeconds(e.RetryAfter)
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.JSON(http.StatusLocked, gin.H{
+			"error":       "account_locked",
+			"message":     "Account is temporarily locked after too many failed login attempts",
+			"retry_after": retryAfter,
+		})
+		return true
+	case services.LoginThrottledError:
+		retryAfter := retryAfterSeconds(e.RetryAfter)
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error":       "too_many_attempts",
+			"message":     "Too many failed login attempts; please wait before trying again",
+			"retry_after": retryAfter,
+		})
+		return true
+	}
+	return false
+}
+
+func (h *AuthHandler) handleAccountError(c *gin.Context, err error, defaultMessage string) {
+	statusCode := http.StatusInternalServerError
+	errorType := "internal_error"
+	message := defaultMessage
+
+	switch err {
+	case services.ErrUserNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "user_not_found"
+		message = "User not found"
+	case services.ErrUserInactive:
+		statusCode = http.StatusForbidden
+		errorType = "account_inactive"
+		message = "Account is inactive"
+	case services.ErrMFAAlreadyEnabled:
+		statusCode = http.StatusConflict
+		errorType = "mfa_already_enabled"
+		message = "Two-factor authentication is already enabled"
+	case services.ErrMFANotEnrolled:
+		statusCode = http.StatusBadRequest
+		errorType = "mfa_not_enrolled"
+		message = "Start two-factor enrollment before confirming it"
+	case services.ErrMFANotEnabled:
+		statusCode = http.StatusBadRequest
+		errorType = "mfa_not_enabled"
+		message = "Two-factor authentication is not enabled"
+	case services.ErrInvalidMFACode:
+		statusCode = http.StatusUnauthorized
+		errorType = "invalid_mfa_code"
+		message = "Invalid two-factor authentication code"
+	case services.ErrInvalidMFAToken:
+		statusCode = http.StatusUnauthorized
+		errorType = "invalid_mfa_token"
+		message = "Two-factor session is invalid or has expired; log in again"
+	case services.ErrSessionNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "session_not_found"
+		message = "Session not found"
+	default:
+		if validationErr, ok := err.(services.ValidationErrors); ok {
+			statusCode = http.StatusBadRequest
+			errorType = "validation_error"
+			message = "Validation failed"
+			c.JSON(statusCode, gin.H{
+				"error":   errorType,
+				"message": message,
+				"details": validationErr.Errors,
+			})
+			return
+		}
+	}
+
+	c.JSON(statusCode, gin.H{
+		"error":   errorType,
+		"message": message,
+	})
+}
+
+func retryAfterSeconds(d time.Duration) int {
+	seconds := int(math.Ceil(d.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return seconds
+}
+
+func clientInfo(c *gin.Context) services.ClientInfo {
+	return services.ClientInfo{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
 }
\ No newline at end of file
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"net/http"
+
+	"task-api/middleware"
+	"task-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommentHandler struct {
+	commentService services.CommentService
+}
+
+func NewCommentHandler(commentService services.CommentService) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+	}
+}
+
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	result, err := h.service(c).ListComments(userID, taskID, getPaginationParams(c))
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get comments")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comments retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	var dto services.CreateCommentDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service(c).CreateComment(userID, taskID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Comment creation failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Comment created successfully",
+		"data":    result,
+	})
+}
+
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, commentID, ok := h.getIDParams(c)
+	if !ok {
+		return
+	}
+
+	var dto services.UpdateCommentDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service(c).UpdateComment(userID, taskID, commentID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Comment update failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comment updated successfully",
+		"data":    result,
+	})
+}
+
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, commentID, ok := h.getIDParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service(c).DeleteComment(userID, taskID, commentID); err != nil {
+		h.handleServiceError(c, err, "Comment deletion failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comment deleted successfully",
+	})
+}
+
+func (h *CommentHandler) getIDParams(c *gin.Context) (uint, uint, bool) {
+	taskID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return 0, 0, false
+	}
+
+	commentID, err := getIDParam(c, "commentId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_comment_id",
+			"message": "Invalid comment ID",
+		})
+		return 0, 0, false
+	}
+
+	return taskID, commentID, true
+}
+
+func (h *CommentHandler) service(c *gin.Context) services.CommentService {
+	return h.commentService.InWorkspace(middleware.GetWorkspaceID(c))
+}
+
+func (h *CommentHandler) handleServiceError(c *gin.Context, err error, defaultMessage string) {
+	statusCode := http.StatusInternalServerError
+	errorType := "internal_error"
+	message := defaultMessage
+
+	switch err {
+	case services.ErrTaskNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "task_not_found"
+		message = "Task not found"
+	case services.ErrUnauthorizedAccess:
+		statusCode = http.StatusForbidden
+		errorType = "unauthorized_access"
+		message = "You don't have permission to access this task"
+	case services.ErrCommentNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "comment_not_found"
+		message = "Comment not found"
+	case services.ErrNotCommentAuthor:
+		statusCode = http.StatusForbidden
+		errorType = "not_comment_author"
+		message = "Only the author can edit or delete this comment"
+	default:
+		if validationErr, ok := err.(services.ValidationErrors); ok {
+			statusCode = http.StatusBadRequest
+			errorType = "validation_error"
+			message = "Validation failed"
+			c.JSON(statusCode, gin.H{
+				"error":   errorType,
+				"message": message,
+				"details": validationErr.Errors,
+			})
+			return
+		}
+	}
+
+	c.JSON(statusCode, gin.H{
+		"error":   errorType,
+		"message": message,
+	})
+}
\ No newline at end of file
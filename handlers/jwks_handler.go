@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+
+	"task-api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSHandler struct{}
+
+func NewJWKSHandler() *JWKSHandler {
+	return &JWKSHandler{}
+}
+
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	jwks, err := utils.GetJWKS()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to load signing keys",
+		})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, jwks)
+}
\ No newline at end of file
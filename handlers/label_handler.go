@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"net/http"
+
+	"task-api/middleware"
+	"task-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LabelHandler struct {
+	labelService services.LabelService
+}
+
+func NewLabelHandler(labelService services.LabelService) *LabelHandler {
+	return &LabelHandler{
+		labelService: labelService,
+	}
+}
+
+func (h *LabelHandler) CreateLabel(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	var dto services.CreateLabelDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.labelService.CreateLabel(userID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Label creation failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Label created successfully",
+		"data":    result,
+	})
+}
+
+func (h *LabelHandler) ListLabels(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	result, err := h.labelService.ListLabels(userID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get labels")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Labels retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *LabelHandler) GetLabel(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	labelID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_label_id",
+			"message": "Invalid label ID",
+		})
+		return
+	}
+
+	result, err := h.labelService.GetLabel(userID, labelID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get label")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Label retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *LabelHandler) UpdateLabel(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	labelID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_label_id",
+			"message": "Invalid label ID",
+		})
+		return
+	}
+
+	var dto services.UpdateLabelDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.labelService.UpdateLabel(userID, labelID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Label update failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Label updated successfully",
+		"data":    result,
+	})
+}
+
+func (h *LabelHandler) DeleteLabel(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	labelID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_label_id",
+			"message": "Invalid label ID",
+		})
+		return
+	}
+
+	if err := h.labelService.DeleteLabel(userID, labelID); err != nil {
+		h.handleServiceError(c, err, "Label deletion failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Label deleted successfully",
+	})
+}
+
+func (h *LabelHandler) handleServiceError(c *gin.Context, err error, defaultMessage string) {
+	statusCode := http.StatusInternalServerError
+	errorType := "internal_error"
+	message := defaultMessage
+
+	switch err {
+	case services.ErrLabelNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "label_not_found"
+		message = "Label not found"
+	case services.ErrLabelNameTaken:
+		statusCode = http.StatusConflict
+		errorType = "label_name_taken"
+		message = "A label with this name already exists"
+	default:
+		if validationErr, ok := err.(services.ValidationErrors); ok {
+			statusCode = http.StatusBadRequest
+			errorType = "validation_error"
+			message = "Validation failed"
+			c.JSON(statusCode, gin.H{
+				"error":   errorType,
+				"message": message,
+				"details": validationErr.Errors,
+			})
+			return
+		}
+	}
+
+	c.JSON(statusCode, gin.H{
+		"error":   errorType,
+		"message": message,
+	})
+}
\ No newline at end of file
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"task-api/middleware"
+	"task-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationService services.NotificationService
+}
+
+func NewNotificationHandler(notificationService services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	unreadOnly, _ := strconv.ParseBool(c.Query("unread"))
+	pagination := getPaginationParams(c)
+
+	result, err := h.notificationService.ListNotifications(userID, unreadOnly, pagination)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get notifications")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notifications retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	notificationID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_notification_id",
+			"message": "Invalid notification ID",
+		})
+		return
+	}
+
+	result, err := h.notificationService.MarkRead(userID, notificationID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to mark notification as read")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification marked as read",
+		"data":    result,
+	})
+}
+
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	if err := h.notificationService.MarkAllRead(userID); err != nil {
+		h.handleServiceError(c, err, "Failed to mark notifications as read")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "All notifications marked as read",
+	})
+}
+
+func (h *NotificationHandler) handleServiceError(c *gin.Context, err error, defaultMessage string) {
+	statusCode := http.StatusInternalServerError
+	errorType := "internal_error"
+	message := defaultMessage
+
+	switch err {
+	case services.ErrNotificationNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "notification_not_found"
+		message = "Notification not found"
+	default:
+		if validationErr, ok := err.(services.ValidationErrors); ok {
+			statusCode = http.StatusBadRequest
+			errorType = "validation_error"
+			message = "Validation failed"
+			c.JSON(statusCode, gin.H{
+				"error":   errorType,
+				"message": message,
+				"details": validationErr.Errors,
+			})
+			return
+		}
+	}
+
+	c.JSON(statusCode, gin.H{
+		"error":   errorType,
+		"message": message,
+	})
+}
\ No newline at end of file
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"strconv"
+
+	"task-api/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getIDParam(c *gin.Context, name string) (uint, error) {
+	idParam := c.Param(name)
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+func getPaginationParams(c *gin.Context) repositories.PaginationParams {
+	page := 1
+	pageSize := 10
+
+	if pageParam := c.Query("page"); pageParam != "" {
+		if p, err := strconv.Atoi(pageParam); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if pageSizeParam := c.Query("page_size"); pageSizeParam != "" {
+		if ps, err := strconv.Atoi(pageSizeParam); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		}
+	}
+
+	after := c.Query("after")
+	before := c.Query("before")
+	cursorMode := after != "" || before != "" || c.Query("pagination") == string(repositories.PaginationModeCursor)
+
+	if cursorMode {
+		includeTotal, _ := strconv.ParseBool(c.Query("include_total"))
+		return repositories.NewCursorPaginationParams(after, before, pageSize, includeTotal)
+	}
+
+	return repositories.NewPaginationParams(page, pageSize)
+}
\ No newline at end of file
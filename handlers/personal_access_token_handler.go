@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"net/http"
+
+	"task-api/middleware"
+	"task-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PersonalAccessTokenHandler struct {
+	tokenService services.PersonalAccessTokenService
+}
+
+func NewPersonalAccessTokenHandler(tokenService services.PersonalAccessTokenService) *PersonalAccessTokenHandler {
+	return &PersonalAccessTokenHandler{
+		tokenService: tokenService,
+	}
+}
+
+func (h *PersonalAccessTokenHandler) CreateToken(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	var dto services.CreatePersonalAccessTokenDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.tokenService.CreateToken(userID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Token creation failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Token created successfully; copy it now, it will not be shown again",
+		"data":    result,
+	})
+}
+
+func (h *PersonalAccessTokenHandler) ListTokens(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	result, err := h.tokenService.ListTokens(userID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get tokens")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tokens retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *PersonalAccessTokenHandler) GetToken(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	tokenID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_token_id",
+			"message": "Invalid token ID",
+		})
+		return
+	}
+
+	result, err := h.tokenService.GetToken(userID, tokenID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get token")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Token retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *PersonalAccessTokenHandler) UpdateToken(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	tokenID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_token_id",
+			"message": "Invalid token ID",
+		})
+		return
+	}
+
+	var dto services.UpdatePersonalAccessTokenDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.tokenService.UpdateToken(userID, tokenID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Token update failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Token updated successfully",
+		"data":    result,
+	})
+}
+
+func (h *PersonalAccessTokenHandler) RevokeToken(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	tokenID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_token_id",
+			"message": "Invalid token ID",
+		})
+		return
+	}
+
+	if err := h.tokenService.RevokeToken(userID, tokenID); err != nil {
+		h.handleServiceError(c, err, "Token revocation failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Token revoked successfully",
+	})
+}
+
+func (h *PersonalAccessTokenHandler) handleServiceError(c *gin.Context, err error, defaultMessage string) {
+	statusCode := http.StatusInternalServerError
+	errorType := "internal_error"
+	message := defaultMessage
+
+	switch err {
+	case services.ErrPersonalAccessTokenNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "token_not_found"
+		message = "Token not found"
+	default:
+		if validationErr, ok := err.(services.ValidationErrors); ok {
+			statusCode = http.StatusBadRequest
+			errorType = "validation_error"
+			message = "Validation failed"
+			c.JSON(statusCode, gin.H{
+				"error":   errorType,
+				"message": message,
+				"details": validationErr.Errors,
+			})
+			return
+		}
+	}
+
+	c.JSON(statusCode, gin.H{
+		"error":   errorType,
+		"message": message,
+	})
+}
\ No newline at end of file
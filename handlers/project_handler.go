@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"task-api/middleware"
+	"task-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectHandler struct {
+	projectService services.ProjectService
+}
+
+func NewProjectHandler(projectService services.ProjectService) *ProjectHandler {
+	return &ProjectHandler{
+		projectService: projectService,
+	}
+}
+
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	var dto services.CreateProjectDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service(c).CreateProject(userID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Project creation failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Project created successfully",
+		"data":    result,
+	})
+}
+
+func (h *ProjectHandler) ListProjects(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	includeArchived, _ := strconv.ParseBool(c.Query("include_archived"))
+
+	result, err := h.service(c).ListProjects(userID, includeArchived)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get projects")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Projects retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	projectID, ok := h.projectIDParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service(c).GetProject(userID, projectID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get project")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Project retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	projectID, ok := h.projectIDParam(c)
+	if !ok {
+		return
+	}
+
+	var dto services.UpdateProjectDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service(c).UpdateProject(userID, projectID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Project update failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Project updated successfully",
+		"data":    result,
+	})
+}
+
+func (h *ProjectHandler) ArchiveProject(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	projectID, ok := h.projectIDParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service(c).ArchiveProject(userID, projectID)
+	if err != nil {
+		h.handleServiceError(c, err, "Project archiving failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Project archived successfully",
+		"data":    result,
+	})
+}
+
+func (h *ProjectHandler) UnarchiveProject(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	projectID, ok := h.projectIDParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service(c).UnarchiveProject(userID, projectID)
+	if err != nil {
+		h.handleServiceError(c, err, "Project restore failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Project restored successfully",
+		"data":    result,
+	})
+}
+
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	projectID, ok := h.projectIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service(c).DeleteProject(userID, projectID); err != nil {
+		h.handleServiceError(c, err, "Project deletion failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Project deleted successfully",
+	})
+}
+
+func (h *ProjectHandler) projectIDParam(c *gin.Context) (uint, bool) {
+	projectID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_project_id",
+			"message": "Invalid project ID",
+		})
+		return 0, false
+	}
+	return projectID, true
+}
+
+func (h *ProjectHandler) service(c *gin.Context) services.ProjectService {
+	return h.projectService.InWorkspace(middleware.GetWorkspaceID(c))
+}
+
+func (h *ProjectHandler) handleServiceError(c *gin.Context, err error, defaultMessage string) {
+	statusCode := http.StatusInternalServerError
+	errorType := "internal_error"
+	message := defaultMessage
+
+	switch err {
+	case services.ErrProjectNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "project_not_found"
+		message = "Project not found"
+	default:
+		if validationErr, ok := err.(services.ValidationErrors); ok {
+			statusCode = http.StatusBadRequest
+			errorType = "validation_error"
+			message = "Validation failed"
+			c.JSON(statusCode, gin.H{
+				"error":   errorType,
+				"message": message,
+				"details": validationErr.Errors,
+			})
+			return
+		}
+	}
+
+	c.JSON(statusCode, gin.H{
+		"error":   errorType,
+		"message": message,
+	})
+}
\ No newline at end of file
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"net/http"
+
+	"task-api/middleware"
+	"task-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderHandler struct {
+	reminderService services.ReminderService
+}
+
+func NewReminderHandler(reminderService services.ReminderService) *ReminderHandler {
+	return &ReminderHandler{
+		reminderService: reminderService,
+	}
+}
+
+func (h *ReminderHandler) CreateReminder(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	var dto services.CreateReminderDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service(c).CreateReminder(userID, taskID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Reminder creation failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Reminder created successfully",
+		"data":    result,
+	})
+}
+
+func (h *ReminderHandler) ListReminders(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	result, err := h.service(c).ListReminders(userID, taskID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get reminders")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Reminders retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *ReminderHandler) DeleteReminder(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	reminderID, err := getIDParam(c, "reminderId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_reminder_id",
+			"message": "Invalid reminder ID",
+		})
+		return
+	}
+
+	if err := h.service(c).DeleteReminder(userID, taskID, reminderID); err != nil {
+		h.handleServiceError(c, err, "Reminder deletion failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Reminder deleted successfully",
+	})
+}
+
+func (h *ReminderHandler) service(c *gin.Context) services.ReminderService {
+	return h.reminderService.InWorkspace(middleware.GetWorkspaceID(c))
+}
+
+func (h *ReminderHandler) handleServiceError(c *gin.Context, err error, defaultMessage string) {
+	statusCode := http.StatusInternalServerError
+	errorType := "internal_error"
+	message := defaultMessage
+
+	switch err {
+	case services.ErrTaskNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "task_not_found"
+		message = "Task not found"
+	case services.ErrUnauthorizedAccess:
+		statusCode = http.StatusForbidden
+		errorType = "unauthorized_access"
+		message = "You don't have permission to access this task"
+	case services.ErrTaskAlreadyCompleted:
+		statusCode = http.StatusConflict
+		errorType = "task_already_completed"
+		message = "Task is already completed"
+	case services.ErrReminderNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "reminder_not_found"
+		message = "Reminder not found"
+	default:
+		if validationErr, ok := err.(services.ValidationErrors); ok {
+			statusCode = http.StatusBadRequest
+			errorType = "validation_error"
+			message = "Validation failed"
+			c.JSON(statusCode, gin.H{
+				"error":   errorType,
+				"message": message,
+				"details": validationErr.Errors,
+			})
+			return
+		}
+	}
+
+	c.JSON(statusCode, gin.H{
+		"error":   errorType,
+		"message": message,
+	})
+}
\ No newline at end of file
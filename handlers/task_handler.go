@@ -5,7 +5,6 @@ import (
 	"strconv"
 
 	"task-api/middleware"
-	"task-api/repositories"
 	"task-api/services"
 
 	"github.com/gin-gonic/gin"
@@ -37,7 +36,7 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		return
 	}
 
-	result, err := h.taskService.CreateTask(userID, dto)
+	result, err := h.service(c).CreateTask(userID, dto)
 	if err != nil {
 		h.handleServiceError(c, err, "Task creation failed")
 		return
@@ -64,7 +63,7 @@ func (h *TaskHandler) GetTask(c *gin.Context) {
 		return
 	}
 
-	result, err := h.taskService.GetTaskByID(userID, taskID)
+	result, err := h.service(c).GetTaskByID(userID, taskID)
 	if err != nil {
 		h.handleServiceError(c, err, "Failed to get task")
 		return
@@ -101,7 +100,9 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		return
 	}
 
-	result, err := h.taskService.UpdateTask(userID, taskID, dto)
+	dto.IgnoreBlockers, _ = strconv.ParseBool(c.Query("ignore_blockers"))
+
+	result, err := h.service(c).UpdateTask(userID, taskID, dto)
 	if err != nil {
 		h.handleServiceError(c, err, "Task update failed")
 		return
@@ -128,7 +129,7 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 		return
 	}
 
-	err = h.taskService.DeleteTask(userID, taskID)
+	err = h.service(c).DeleteTask(userID, taskID)
 	if err != nil {
 		h.handleServiceError(c, err, "Task deletion failed")
 		return
@@ -145,9 +146,19 @@ func (h *TaskHandler) GetUserTasks(c *gin.Context) {
 		return
 	}
 
-	pagination := h.getPaginationParams(c)
+	var query services.TaskListQueryDTO
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	pagination := getPaginationParams(c)
 
-	result, err := h.taskService.GetUserTasks(userID, pagination)
+	result, err := h.service(c).GetUserTasks(userID, query, pagination)
 	if err != nil {
 		h.handleServiceError(c, err, "Failed to get tasks")
 		return
@@ -159,10 +170,211 @@ func (h *TaskHandler) GetUserTasks(c *gin.Context) {
 	})
 }
 
+func (h *TaskHandler) GetSubtasks(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := h.getTaskIDFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	result, err := h.service(c).GetSubtasks(userID, taskID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get subtasks")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Subtasks retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *TaskHandler) GetTaskHistory(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := h.getTaskIDFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	result, err := h.service(c).GetTaskHistory(userID, taskID, getPaginationParams(c))
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get task history")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Task history retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *TaskHandler) ListDependencies(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := h.getTaskIDFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	result, err := h.service(c).ListDependencies(userID, taskID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get dependencies")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Dependencies retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *TaskHandler) AddDependency(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := h.getTaskIDFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	var dto services.AddTaskDependencyDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service(c).AddDependency(userID, taskID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to add dependency")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Dependency added successfully",
+		"data":    result,
+	})
+}
+
+func (h *TaskHandler) RemoveDependency(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := h.getTaskIDFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	blockerID, err := getIDParam(c, "blockerId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid blocking task ID",
+		})
+		return
+	}
+
+	if err := h.service(c).RemoveDependency(userID, taskID, blockerID); err != nil {
+		h.handleServiceError(c, err, "Failed to remove dependency")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Dependency removed successfully",
+	})
+}
+
+func (h *TaskHandler) GetProjectTasks(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	projectID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_project_id",
+			"message": "Invalid project ID",
+		})
+		return
+	}
+
+	var query services.TaskListQueryDTO
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	pagination := getPaginationParams(c)
+
+	result, err := h.service(c).GetProjectTasks(userID, projectID, query, pagination)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get project tasks")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tasks retrieved successfully",
+		"data":    result,
+	})
+}
+
 func (h *TaskHandler) GetAllTasks(c *gin.Context) {
-	pagination := h.getPaginationParams(c)
+	var query services.TaskListQueryDTO
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	pagination := getPaginationParams(c)
 
-	result, err := h.taskService.GetAllTasks(pagination)
+	result, err := h.taskService.GetAllTasks(query, pagination)
 	if err != nil {
 		h.handleServiceError(c, err, "Failed to get all tasks")
 		return
@@ -189,7 +401,13 @@ func (h *TaskHandler) CompleteTask(c *gin.Context) {
 		return
 	}
 
-	result, err := h.taskService.CompleteTask(userID, taskID)
+	ignoreBlockers, _ := strconv.ParseBool(c.Query("ignore_blockers"))
+	options := services.CompleteTaskOptions{
+		Subtasks:       services.SubtaskCompletionMode(c.Query("subtasks")),
+		IgnoreBlockers: ignoreBlockers,
+	}
+
+	result, err := h.service(c).CompleteTask(userID, taskID, options)
 	if err != nil {
 		h.handleServiceError(c, err, "Task completion failed")
 		return
@@ -201,32 +419,164 @@ func (h *TaskHandler) CompleteTask(c *gin.Context) {
 	})
 }
 
-func (h *TaskHandler) getTaskIDFromParam(c *gin.Context) (uint, error) {
-	idParam := c.Param("id")
-	id, err := strconv.ParseUint(idParam, 10, 32)
+func (h *TaskHandler) SkipOccurrence(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := h.getTaskIDFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	result, err := h.service(c).SkipOccurrence(userID, taskID)
 	if err != nil {
-		return 0, err
+		h.handleServiceError(c, err, "Failed to skip occurrence")
+		return
 	}
-	return uint(id), nil
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Occurrence skipped successfully",
+		"data":    result,
+	})
 }
 
-func (h *TaskHandler) getPaginationParams(c *gin.Context) repositories.PaginationParams {
-	page := 1
-	pageSize := 10
+func (h *TaskHandler) EndRecurrence(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
 
-	if pageParam := c.Query("page"); pageParam != "" {
-		if p, err := strconv.Atoi(pageParam); err == nil && p > 0 {
-			page = p
-		}
+	taskID, err := h.getTaskIDFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
 	}
 
-	if pageSizeParam := c.Query("page_size"); pageSizeParam != "" {
-		if ps, err := strconv.Atoi(pageSizeParam); err == nil && ps > 0 && ps <= 100 {
-			pageSize = ps
-		}
+	result, err := h.service(c).EndRecurrence(userID, taskID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to end recurrence")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Recurrence ended successfully",
+		"data":    result,
+	})
+}
+
+func (h *TaskHandler) ListShares(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := h.getTaskIDFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	result, err := h.service(c).ListShares(userID, taskID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to retrieve shares")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shares retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *TaskHandler) ShareTask(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := h.getTaskIDFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	var dto services.ShareTaskDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service(c).ShareTask(userID, taskID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to share task")
+		return
 	}
 
-	return repositories.NewPaginationParams(page, pageSize)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Task shared successfully",
+		"data":    result,
+	})
+}
+
+func (h *TaskHandler) UnshareTask(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	taskID, err := h.getTaskIDFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_task_id",
+			"message": "Invalid task ID",
+		})
+		return
+	}
+
+	shareUserID, err := getIDParam(c, "userId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_user_id",
+			"message": "Invalid user ID",
+		})
+		return
+	}
+
+	if err := h.service(c).UnshareTask(userID, taskID, shareUserID); err != nil {
+		h.handleServiceError(c, err, "Failed to remove share")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Share removed successfully",
+	})
+}
+
+func (h *TaskHandler) service(c *gin.Context) services.TaskService {
+	return h.taskService.InWorkspace(middleware.GetWorkspaceID(c))
+}
+
+func (h *TaskHandler) getTaskIDFromParam(c *gin.Context) (uint, error) {
+	return getIDParam(c, "id")
 }
 
 func (h *TaskHandler) handleServiceError(c *gin.Context, err error, defaultMessage string) {
@@ -251,11 +601,69 @@ func (h *TaskHandler) handleServiceError(c *gin.Context, err error, defaultMessa
 		statusCode = http.StatusBadRequest
 		errorType = "invalid_due_date"
 		message = "Due date cannot be in the past"
+	case services.ErrEmailNotVerified:
+		statusCode = http.StatusForbidden
+		errorType = "email_not_verified"
+		message = "Please verify your email address before creating tasks"
+	case services.ErrOpenSubtasks:
+		statusCode = http.StatusConflict
+		errorType = "open_subtasks"
+		message = "Task has open subtasks; complete them first or pass subtasks=cascade"
+	case services.ErrDependencyExists:
+		statusCode = http.StatusConflict
+		errorType = "dependency_exists"
+		message = "This dependency already exists"
+	case services.ErrDependencyNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "dependency_not_found"
+		message = "Dependency not found"
+	case services.ErrDependencyCycle:
+		statusCode = http.StatusConflict
+		errorType = "dependency_cycle"
+		message = "This dependency would create a cycle"
+	case services.ErrTaskNotRecurring:
+		statusCode = http.StatusConflict
+		errorType = "task_not_recurring"
+		message = "Task is not recurring"
+	case services.ErrRecurrenceFinished:
+		statusCode = http.StatusConflict
+		errorType = "recurrence_finished"
+		message = "The recurrence has no further occurrences"
+	case services.ErrShareNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "share_not_found"
+		message = "Task is not shared with this user"
+	case services.ErrProjectNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "project_not_found"
+		message = "Project not found"
+	case services.ErrProjectArchived:
+		statusCode = http.StatusConflict
+		errorType = "project_archived"
+		message = "Tasks cannot be added to an archived project"
 	case services.ErrInvalidInput:
 		statusCode = http.StatusBadRequest
 		errorType = "invalid_input"
 		message = "Invalid input data"
 	default:
+		if transitionErr, ok := err.(services.InvalidTransitionError); ok {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "invalid_transition",
+				"message": "Task cannot move from " + string(transitionErr.From) + " to " + string(transitionErr.To),
+				"from":    transitionErr.From,
+				"to":      transitionErr.To,
+				"allowed": transitionErr.Allowed,
+			})
+			return
+		}
+		if blockedErr, ok := err.(services.TaskBlockedError); ok {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":      "task_blocked",
+				"message":    "Task is blocked by open tasks; finish them first or pass ignore_blockers=true",
+				"blocked_by": blockedErr.BlockerIDs,
+			})
+			return
+		}
 		if validationErr, ok := err.(services.ValidationErrors); ok {
 			statusCode = http.StatusBadRequest
 			errorType = "validation_error"
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+
+	"task-api/middleware"
+	"task-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	userService services.UserService
+}
+
+func NewUserHandler(userService services.UserService) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+	}
+}
+
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	pagination := getPaginationParams(c)
+
+	result, err := h.userService.ListUsers(pagination)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get users")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Users retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *UserHandler) UpdateUserRole(c *gin.Context) {
+	actorID := middleware.RequireUserID(c)
+	if actorID == 0 {
+		return
+	}
+
+	userID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_user_id",
+			"message": "Invalid user ID",
+		})
+		return
+	}
+
+	var dto services.UpdateUserRoleDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.userService.UpdateUserRole(actorID, userID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Role update failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User role updated successfully",
+		"data":    result,
+	})
+}
+
+func (h *UserHandler) UnlockUser(c *gin.Context) {
+	userID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_user_id",
+			"message": "Invalid user ID",
+		})
+		return
+	}
+
+	if err := h.userService.UnlockUser(userID); err != nil {
+		h.handleServiceError(c, err, "Failed to unlock user")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User unlocked successfully",
+	})
+}
+
+func (h *UserHandler) handleServiceError(c *gin.Context, err error, defaultMessage string) {
+	statusCode := http.StatusInternalServerError
+	errorType := "internal_error"
+	message := defaultMessage
+
+	switch err {
+	case services.ErrUserNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "user_not_found"
+		message = "User not found"
+	case services.ErrInvalidRole:
+		statusCode = http.StatusBadRequest
+		errorType = "invalid_role"
+		message = "Role must be one of user, admin, support"
+	case services.ErrCannotChangeOwnRole:
+		statusCode = http.StatusConflict
+		errorType = "cannot_change_own_role"
+		message = "You cannot change your own role"
+	}
+
+	c.JSON(statusCode, gin.H{
+		"error":   errorType,
+		"message": message,
+	})
+}
\ No newline at end of file
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"net/http"
+
+	"task-api/middleware"
+	"task-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkflowHandler struct {
+	workflowService services.WorkflowService
+}
+
+func NewWorkflowHandler(workflowService services.WorkflowService) *WorkflowHandler {
+	return &WorkflowHandler{
+		workflowService: workflowService,
+	}
+}
+
+func (h *WorkflowHandler) GetWorkspaceWorkflow(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	result, err := h.service(c).GetWorkspaceWorkflow()
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get workflow")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workflow retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *WorkflowHandler) ReplaceWorkspaceWorkflow(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	var dto services.ReplaceWorkflowDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service(c).ReplaceWorkspaceWorkflow(userID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Workflow update failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workflow updated successfully",
+		"data":    result,
+	})
+}
+
+func (h *WorkflowHandler) ResetWorkspaceWorkflow(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	result, err := h.service(c).ResetWorkspaceWorkflow(userID)
+	if err != nil {
+		h.handleServiceError(c, err, "Workflow reset failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workflow reset successfully",
+		"data":    result,
+	})
+}
+
+func (h *WorkflowHandler) GetProjectWorkflow(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	projectID, ok := h.projectIDParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service(c).GetProjectWorkflow(userID, projectID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get workflow")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workflow retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *WorkflowHandler) ReplaceProjectWorkflow(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	projectID, ok := h.projectIDParam(c)
+	if !ok {
+		return
+	}
+
+	var dto services.ReplaceWorkflowDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service(c).ReplaceProjectWorkflow(userID, projectID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Workflow update failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workflow updated successfully",
+		"data":    result,
+	})
+}
+
+func (h *WorkflowHandler) ResetProjectWorkflow(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	projectID, ok := h.projectIDParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service(c).ResetProjectWorkflow(userID, projectID)
+	if err != nil {
+		h.handleServiceError(c, err, "Workflow reset failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workflow reset successfully",
+		"data":    result,
+	})
+}
+
+func (h *WorkflowHandler) projectIDParam(c *gin.Context) (uint, bool) {
+	projectID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_project_id",
+			"message": "Invalid project ID",
+		})
+		return 0, false
+	}
+	return projectID, true
+}
+
+func (h *WorkflowHandler) service(c *gin.Context) services.WorkflowService {
+	return h.workflowService.InWorkspace(middleware.GetWorkspaceID(c))
+}
+
+func (h *WorkflowHandler) handleServiceError(c *gin.Context, err error, defaultMessage string) {
+	statusCode := http.StatusInternalServerError
+	errorType := "internal_error"
+	message := defaultMessage
+
+	switch err {
+	case services.ErrProjectNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "project_not_found"
+		message = "Project not found"
+	default:
+		if validationErr, ok := err.(services.ValidationErrors); ok {
+			statusCode = http.StatusBadRequest
+			errorType = "validation_error"
+			message = "Validation failed"
+			c.JSON(statusCode, gin.H{
+				"error":   errorType,
+				"message": message,
+				"details": validationErr.Errors,
+			})
+			return
+		}
+	}
+
+	c.JSON(statusCode, gin.H{
+		"error":   errorType,
+		"message": message,
+	})
+}
\ No newline at end of file
@@ -0,0 +1,414 @@
+package handlers
+
+import (
+	"net/http"
+
+	"task-api/middleware"
+	"task-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkspaceHandler struct {
+	workspaceService services.WorkspaceService
+}
+
+func NewWorkspaceHandler(workspaceService services.WorkspaceService) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		workspaceService: workspaceService,
+	}
+}
+
+func (h *WorkspaceHandler) ListWorkspaces(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	result, err := h.workspaceService.ListWorkspaces(userID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get workspaces")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workspaces retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *WorkspaceHandler) CreateWorkspace(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	var dto services.CreateWorkspaceDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.workspaceService.CreateWorkspace(userID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Workspace creation failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Workspace created successfully",
+		"data":    result,
+	})
+}
+
+func (h *WorkspaceHandler) GetWorkspace(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	workspaceID, ok := h.idParam(c, "id", "invalid_workspace_id", "Invalid workspace ID")
+	if !ok {
+		return
+	}
+
+	result, err := h.workspaceService.GetWorkspace(userID, workspaceID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get workspace")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workspace retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *WorkspaceHandler) UpdateWorkspace(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	workspaceID, ok := h.idParam(c, "id", "invalid_workspace_id", "Invalid workspace ID")
+	if !ok {
+		return
+	}
+
+	var dto services.UpdateWorkspaceDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.workspaceService.UpdateWorkspace(userID, workspaceID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Workspace update failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workspace updated successfully",
+		"data":    result,
+	})
+}
+
+func (h *WorkspaceHandler) DeleteWorkspace(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	workspaceID, ok := h.idParam(c, "id", "invalid_workspace_id", "Invalid workspace ID")
+	if !ok {
+		return
+	}
+
+	if err := h.workspaceService.DeleteWorkspace(userID, workspaceID); err != nil {
+		h.handleServiceError(c, err, "Workspace deletion failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workspace deleted successfully",
+	})
+}
+
+func (h *WorkspaceHandler) ListMembers(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	workspaceID, ok := h.idParam(c, "id", "invalid_workspace_id", "Invalid workspace ID")
+	if !ok {
+		return
+	}
+
+	result, err := h.workspaceService.ListMembers(userID, workspaceID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get members")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Members retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *WorkspaceHandler) UpdateMember(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	workspaceID, ok := h.idParam(c, "id", "invalid_workspace_id", "Invalid workspace ID")
+	if !ok {
+		return
+	}
+
+	memberUserID, ok := h.idParam(c, "userId", "invalid_user_id", "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	var dto services.UpdateWorkspaceMemberDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.workspaceService.UpdateMember(userID, workspaceID, memberUserID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Member update failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Member updated successfully",
+		"data":    result,
+	})
+}
+
+func (h *WorkspaceHandler) RemoveMember(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	workspaceID, ok := h.idParam(c, "id", "invalid_workspace_id", "Invalid workspace ID")
+	if !ok {
+		return
+	}
+
+	memberUserID, ok := h.idParam(c, "userId", "invalid_user_id", "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	if err := h.workspaceService.RemoveMember(userID, workspaceID, memberUserID); err != nil {
+		h.handleServiceError(c, err, "Failed to remove member")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Member removed successfully",
+	})
+}
+
+func (h *WorkspaceHandler) ListInvitations(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	workspaceID, ok := h.idParam(c, "id", "invalid_workspace_id", "Invalid workspace ID")
+	if !ok {
+		return
+	}
+
+	result, err := h.workspaceService.ListInvitations(userID, workspaceID)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to get invitations")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invitations retrieved successfully",
+		"data":    result,
+	})
+}
+
+func (h *WorkspaceHandler) InviteMember(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	workspaceID, ok := h.idParam(c, "id", "invalid_workspace_id", "Invalid workspace ID")
+	if !ok {
+		return
+	}
+
+	var dto services.InviteWorkspaceMemberDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.workspaceService.InviteMember(userID, workspaceID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to send invitation")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Invitation sent successfully",
+		"data":    result,
+	})
+}
+
+func (h *WorkspaceHandler) RevokeInvitation(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	workspaceID, ok := h.idParam(c, "id", "invalid_workspace_id", "Invalid workspace ID")
+	if !ok {
+		return
+	}
+
+	invitationID, ok := h.idParam(c, "invitationId", "invalid_invitation_id", "Invalid invitation ID")
+	if !ok {
+		return
+	}
+
+	if err := h.workspaceService.RevokeInvitation(userID, workspaceID, invitationID); err != nil {
+		h.handleServiceError(c, err, "Failed to revoke invitation")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invitation revoked successfully",
+	})
+}
+
+func (h *WorkspaceHandler) AcceptInvitation(c *gin.Context) {
+	userID := middleware.RequireUserID(c)
+	if userID == 0 {
+		return
+	}
+
+	var dto services.AcceptWorkspaceInvitationDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_input",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.workspaceService.AcceptInvitation(userID, dto)
+	if err != nil {
+		h.handleServiceError(c, err, "Failed to accept invitation")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invitation accepted successfully",
+		"data":    result,
+	})
+}
+
+func (h *WorkspaceHandler) idParam(c *gin.Context, name, errorType, message string) (uint, bool) {
+	id, err := getIDParam(c, name)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   errorType,
+			"message": message,
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *WorkspaceHandler) handleServiceError(c *gin.Context, err error, defaultMessage string) {
+	statusCode := http.StatusInternalServerError
+	errorType := "internal_error"
+	message := defaultMessage
+
+	switch err {
+	case services.ErrWorkspaceNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "workspace_not_found"
+		message = "Workspace not found"
+	case services.ErrWorkspaceMemberNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "member_not_found"
+		message = "Member not found"
+	case services.ErrWorkspaceRoleInsufficient:
+		statusCode = http.StatusForbidden
+		errorType = "workspace_role_insufficient"
+		message = "Your workspace role does not allow this"
+	case services.ErrPersonalWorkspace:
+		statusCode = http.StatusConflict
+		errorType = "personal_workspace"
+		message = "Personal workspaces cannot be deleted or left by their owner"
+	case services.ErrLastWorkspaceOwner:
+		statusCode = http.StatusConflict
+		errorType = "last_workspace_owner"
+		message = "A workspace needs at least one owner"
+	case services.ErrAlreadyWorkspaceMember:
+		statusCode = http.StatusConflict
+		errorType = "already_member"
+		message = "This user is already a member of the workspace"
+	case services.ErrInvitationNotFound:
+		statusCode = http.StatusNotFound
+		errorType = "invitation_not_found"
+		message = "Invitation not found"
+	case services.ErrInvitationExpired:
+		statusCode = http.StatusGone
+		errorType = "invitation_expired"
+		message = "Invitation has expired"
+	case services.ErrInvitationEmailMismatch:
+		statusCode = http.StatusForbidden
+		errorType = "invitation_email_mismatch"
+		message = "This invitation was sent to a different email address"
+	default:
+		if validationErr, ok := err.(services.ValidationErrors); ok {
+			statusCode = http.StatusBadRequest
+			errorType = "validation_error"
+			message = "Validation failed"
+			c.JSON(statusCode, gin.H{
+				"error":   errorType,
+				"message": message,
+				"details": validationErr.Errors,
+			})
+			return
+		}
+	}
+
+	c.JSON(statusCode, gin.H{
+		"error":   errorType,
+		"message": message,
+	})
+}
\ No newline at end of file
@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+type logMailer struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewLogMailer(path string) Mailer {
+	return &logMailer{
+		path: path,
+	}
+}
+
+func (m *logMailer) Send(msg Message) error {
+	entry := fmt.Sprintf("[%s] To: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC3339), msg.To, msg.Subject, msg.Body)
+
+	if m.path == "" {
+		log.Print("Outgoing email:\n" + entry)
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open mail log: %w", err)
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(entry)
+	return err
+}
\ No newline at end of file
@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"os"
+
+	"task-api/utils"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(msg Message) error
+}
+
+func NewFromEnv() Mailer {
+	switch os.Getenv("MAIL_DRIVER") {
+	case "smtp":
+		return NewSMTPMailer(SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     utils.GetEnvWithDefault("SMTP_PORT", "587"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     utils.GetEnvWithDefault("MAIL_FROM", "no-reply@task-api.local"),
+		})
+	default:
+		return NewLogMailer(os.Getenv("MAIL_LOG_FILE"))
+	}
+}
\ No newline at end of file
@@ -11,9 +11,13 @@ import (
 
 	"task-api/database"
 	"task-api/handlers"
+	"task-api/mailer"
 	"task-api/middleware"
+	"task-api/models"
 	"task-api/repositories"
 	"task-api/services"
+	"task-api/storage"
+	"task-api/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -24,6 +28,14 @@ func main() {
 		log.Println("No .env file found")
 	}
 
+	if err := utils.InitKeys(); err != nil {
+		log.Fatal("Failed to load JWT signing keys:", err)
+	}
+
+	if err := utils.InitSecretEncryption(); err != nil {
+		log.Fatal("Failed to load MFA encryption key:", err)
+	}
+
 	if err := database.Connect(); err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
@@ -31,15 +43,93 @@ func main() {
 
 	userRepo := repositories.NewUserRepository(database.DB)
 	taskRepo := repositories.NewTaskRepository(database.DB)
+	refreshTokenRepo := repositories.NewRefreshTokenRepository(database.DB)
+	sessionRepo := repositories.NewSessionRepository(database.DB)
+	oneTimeTokenRepo := repositories.NewOneTimeTokenRepository(database.DB)
+	recoveryCodeRepo := repositories.NewRecoveryCodeRepository(database.DB)
+	personalAccessTokenRepo := repositories.NewPersonalAccessTokenRepository(database.DB)
+	loginAttemptRepo := repositories.NewLoginAttemptRepository(database.DB)
+	labelRepo := repositories.NewLabelRepository(database.DB)
+	projectRepo := repositories.NewProjectRepository(database.DB)
+	taskDependencyRepo := repositories.NewTaskDependencyRepository(database.DB)
+	reminderRepo := repositories.NewReminderRepository(database.DB)
+	notificationRepo := repositories.NewNotificationRepository(database.DB)
+	commentRepo := repositories.NewCommentRepository(database.DB)
+	attachmentRepo := repositories.NewAttachmentRepository(database.DB)
+	taskShareRepo := repositories.NewTaskShareRepository(database.DB)
+	workspaceRepo := repositories.NewWorkspaceRepository(database.DB)
+	workspaceInvitationRepo := repositories.NewWorkspaceInvitationRepository(database.DB)
+	workflowRepo := repositories.NewWorkflowRepository(database.DB)
+	taskActivityRepo := repositories.NewTaskActivityRepository(database.DB)
+
+	allWorkspacesDB := repositories.AcrossWorkspaces(database.DB)
+	systemTaskRepo := repositories.NewTaskRepository(allWorkspacesDB)
+	systemProjectRepo := repositories.NewProjectRepository(allWorkspacesDB)
+	systemTaskDependencyRepo := repositories.NewTaskDependencyRepository(allWorkspacesDB)
+	systemWorkflowRepo := repositories.NewWorkflowRepository(allWorkspacesDB)
+
+	mail := mailer.NewFromEnv()
+	blobStore, err := storage.NewFromEnv()
+	if err != nil {
+		log.Fatal("Failed to configure attachment storage:", err)
+	}
+	verificationPolicy := services.EmailVerificationPolicyFromEnv()
+	throttlePolicy := services.LoginThrottlePolicyFromEnv()
+	reminderSchedulerConfig := services.ReminderSchedulerConfigFromEnv()
+	attachmentPolicy := services.AttachmentPolicyFromEnv()
+	taskPermissions := services.NewTaskPermissions(taskRepo, taskShareRepo)
+	systemTaskPermissions := services.NewTaskPermissions(systemTaskRepo, taskShareRepo)
+	taskStateMachine := services.NewTaskStateMachine()
+
+	authService := services.NewAuthService(userRepo, refreshTokenRepo, sessionRepo, oneTimeTokenRepo, recoveryCodeRepo, loginAttemptRepo, mail, verificationPolicy, throttlePolicy)
+	taskService := services.NewTaskService(taskRepo, userRepo, labelRepo, projectRepo, taskDependencyRepo, commentRepo, taskShareRepo, workspaceRepo, workflowRepo, taskActivityRepo, taskStateMachine, taskPermissions, verificationPolicy)
+	adminTaskService := services.NewTaskService(systemTaskRepo, userRepo, labelRepo, systemProjectRepo, systemTaskDependencyRepo, commentRepo, taskShareRepo, workspaceRepo, systemWorkflowRepo, taskActivityRepo, taskStateMachine, systemTaskPermissions, verificationPolicy)
+	userService := services.NewUserService(userRepo, refreshTokenRepo, sessionRepo, loginAttemptRepo)
+	personalAccessTokenService := services.NewPersonalAccessTokenService(personalAccessTokenRepo, userRepo)
+	labelService := services.NewLabelService(labelRepo)
+	projectService := services.NewProjectService(projectRepo)
+	workflowService := services.NewWorkflowService(workflowRepo, projectRepo)
+	reminderService := services.NewReminderService(reminderRepo, taskPermissions)
+	notificationService := services.NewNotificationService(notificationRepo)
+	commentService := services.NewCommentService(commentRepo, userRepo, taskPermissions)
+	attachmentService := services.NewAttachmentService(attachmentRepo, taskPermissions, blobStore, attachmentPolicy)
+	workspaceService := services.NewWorkspaceService(workspaceRepo, workspaceInvitationRepo, userRepo, mail)
+
+	reminderScheduler := services.NewReminderScheduler(reminderRepo, systemTaskRepo, userRepo, systemTaskPermissions, map[models.ReminderChannel]services.ReminderNotifier{
+		models.ReminderChannelEmail:   services.NewEmailReminderNotifier(mail),
+		models.ReminderChannelWebhook: services.NewWebhookReminderNotifier(services.NewWebhookHTTPClient(10*time.Second), os.Getenv("REMINDER_WEBHOOK_SECRET")),
+		models.ReminderChannelInApp:   services.NewInAppReminderNotifier(notificationRepo),
+	}, reminderSchedulerConfig)
+
+	sessionDenylist := services.NewSessionDenylist(sessionRepo, utils.GetAccessTokenExpiry(), services.DefaultDenylistRefreshInterval)
 
-	authService := services.NewAuthService(userRepo)
-	taskService := services.NewTaskService(taskRepo)
+	middleware.UsePersonalAccessTokens(personalAccessTokenService)
+	middleware.UseSessionDenylist(sessionDenylist)
+	middleware.UseWorkspaces(workspaceService)
 
 	authHandler := handlers.NewAuthHandler(authService)
 	taskHandler := handlers.NewTaskHandler(taskService)
+	adminTaskHandler := handlers.NewTaskHandler(adminTaskService)
+	userHandler := handlers.NewUserHandler(userService)
+	personalAccessTokenHandler := handlers.NewPersonalAccessTokenHandler(personalAccessTokenService)
+	labelHandler := handlers.NewLabelHandler(labelService)
+	projectHandler := handlers.NewProjectHandler(projectService)
+	workflowHandler := handlers.NewWorkflowHandler(workflowService)
+	reminderHandler := handlers.NewReminderHandler(reminderService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	commentHandler := handlers.NewCommentHandler(commentService)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService, attachmentPolicy.MaxFileSize)
+	workspaceHandler := handlers.NewWorkspaceHandler(workspaceService)
+	jwksHandler := handlers.NewJWKSHandler()
 
 	r := gin.Default()
 
+	// Client IPs feed the login throttle and the session list, so
+	// X-Forwarded-For is only honoured from proxies listed here.
+	if err := r.SetTrustedProxies(utils.GetEnvList("TRUSTED_PROXIES")); err != nil {
+		log.Fatal("Failed to configure trusted proxies:", err)
+	}
+
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"status":   "ok",
@@ -49,6 +139,8 @@ func main() {
 		})
 	})
 
+	r.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 	v1 := r.Group("/api/v1")
 	{
 		auth := v1.Group("/auth")
@@ -56,24 +148,140 @@ func main() {
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/login", authHandler.Login)
 			auth.POST("/refresh", authHandler.RefreshToken)
-			auth.GET("/profile", middleware.AuthRequired(), authHandler.GetProfile)
+			auth.POST("/logout", authHandler.Logout)
+			auth.POST("/password/forgot", authHandler.ForgotPassword)
+			auth.POST("/password/reset", authHandler.ResetPassword)
+			auth.POST("/verify-email", authHandler.VerifyEmail)
+			auth.POST("/verify-email/resend", authHandler.ResendVerification)
+			auth.POST("/2fa/verify", authHandler.VerifyMFA)
+		}
+
+		account := v1.Group("/auth")
+		account.Use(middleware.AuthRequired(), middleware.SessionOnly())
+		{
+			account.POST("/logout-all", authHandler.LogoutAll)
+			account.GET("/profile", authHandler.GetProfile)
+			account.PATCH("/profile", authHandler.UpdateProfile)
+			account.POST("/password/change", authHandler.ChangePassword)
+			account.POST("/2fa/enroll", authHandler.EnrollTOTP)
+			account.POST("/2fa/confirm", authHandler.ConfirmTOTP)
+			account.POST("/2fa/disable", authHandler.DisableTOTP)
+
+			account.GET("/sessions", authHandler.ListSessions)
+			account.DELETE("/sessions/:id", authHandler.RevokeSession)
+
+			account.GET("/tokens", personalAccessTokenHandler.ListTokens)
+			account.POST("/tokens", personalAccessTokenHandler.CreateToken)
+			account.GET("/tokens/:id", personalAccessTokenHandler.GetToken)
+			account.PATCH("/tokens/:id", personalAccessTokenHandler.UpdateToken)
+			account.DELETE("/tokens/:id", personalAccessTokenHandler.RevokeToken)
+		}
+
+		readTasks := middleware.RequireScope(models.ScopeTasksRead)
+		writeTasks := middleware.RequireScope(models.ScopeTasksWrite)
+		adminWorkspaces := middleware.RequireScope(models.ScopeWorkspacesAdmin)
+		createContent := middleware.RequireWorkspaceRole(models.WorkspaceRoleOwner, models.WorkspaceRoleAdmin, models.WorkspaceRoleMember)
+
+		workspaces := v1.Group("/workspaces")
+		workspaces.Use(middleware.AuthRequired())
+		{
+			workspaces.GET("", readTasks, workspaceHandler.ListWorkspaces)
+			workspaces.POST("", writeTasks, workspaceHandler.CreateWorkspace)
+			workspaces.POST("/invitations/accept", writeTasks, workspaceHandler.AcceptInvitation)
+			workspaces.GET("/:id", readTasks, workspaceHandler.GetWorkspace)
+			workspaces.PATCH("/:id", adminWorkspaces, workspaceHandler.UpdateWorkspace)
+			workspaces.DELETE("/:id", adminWorkspaces, workspaceHandler.DeleteWorkspace)
+			workspaces.GET("/:id/members", readTasks, workspaceHandler.ListMembers)
+			workspaces.PATCH("/:id/members/:userId", adminWorkspaces, workspaceHandler.UpdateMember)
+			workspaces.DELETE("/:id/members/:userId", adminWorkspaces, workspaceHandler.RemoveMember)
+			workspaces.GET("/:id/invitations", readTasks, workspaceHandler.ListInvitations)
+			workspaces.POST("/:id/invitations", adminWorkspaces, workspaceHandler.InviteMember)
+			workspaces.DELETE("/:id/invitations/:invitationId", adminWorkspaces, workspaceHandler.RevokeInvitation)
 		}
 
 		tasks := v1.Group("/tasks")
-		tasks.Use(middleware.AuthRequired())
+		tasks.Use(middleware.AuthRequired(), middleware.WorkspaceRequired())
+		{
+			tasks.POST("", writeTasks, createContent, taskHandler.CreateTask)
+			tasks.GET("", readTasks, taskHandler.GetUserTasks)
+			tasks.GET("/:id", readTasks, taskHandler.GetTask)
+			tasks.PUT("/:id", writeTasks, taskHandler.UpdateTask)
+			tasks.DELETE("/:id", writeTasks, taskHandler.DeleteTask)
+			tasks.POST("/:id/complete", writeTasks, taskHandler.CompleteTask)
+			tasks.GET("/:id/subtasks", readTasks, taskHandler.GetSubtasks)
+			tasks.GET("/:id/history", readTasks, taskHandler.GetTaskHistory)
+			tasks.GET("/:id/dependencies", readTasks, taskHandler.ListDependencies)
+			tasks.POST("/:id/dependencies", writeTasks, taskHandler.AddDependency)
+			tasks.DELETE("/:id/dependencies/:blockerId", writeTasks, taskHandler.RemoveDependency)
+			tasks.POST("/:id/recurrence/skip", writeTasks, taskHandler.SkipOccurrence)
+			tasks.POST("/:id/recurrence/end", writeTasks, taskHandler.EndRecurrence)
+			tasks.GET("/:id/shares", readTasks, taskHandler.ListShares)
+			tasks.POST("/:id/shares", writeTasks, taskHandler.ShareTask)
+			tasks.DELETE("/:id/shares/:userId", writeTasks, taskHandler.UnshareTask)
+			tasks.GET("/:id/reminders", readTasks, reminderHandler.ListReminders)
+			tasks.POST("/:id/reminders", writeTasks, reminderHandler.CreateReminder)
+			tasks.DELETE("/:id/reminders/:reminderId", writeTasks, reminderHandler.DeleteReminder)
+			tasks.GET("/:id/comments", readTasks, commentHandler.ListComments)
+			tasks.POST("/:id/comments", writeTasks, commentHandler.CreateComment)
+			tasks.PATCH("/:id/comments/:commentId", writeTasks, commentHandler.UpdateComment)
+			tasks.DELETE("/:id/comments/:commentId", writeTasks, commentHandler.DeleteComment)
+			tasks.GET("/:id/attachments", readTasks, attachmentHandler.ListAttachments)
+			tasks.POST("/:id/attachments", writeTasks, attachmentHandler.UploadAttachment)
+			tasks.GET("/:id/attachments/:attachmentId", readTasks, attachmentHandler.DownloadAttachment)
+			tasks.DELETE("/:id/attachments/:attachmentId", writeTasks, attachmentHandler.DeleteAttachment)
+		}
+
+		notifications := v1.Group("/notifications")
+		notifications.Use(middleware.AuthRequired())
 		{
-			tasks.POST("", taskHandler.CreateTask)
-			tasks.GET("", taskHandler.GetUserTasks)
-			tasks.GET("/:id", taskHandler.GetTask)
-			tasks.PUT("/:id", taskHandler.UpdateTask)
-			tasks.DELETE("/:id", taskHandler.DeleteTask)
-			tasks.POST("/:id/complete", taskHandler.CompleteTask)
+			notifications.GET("", readTasks, notificationHandler.ListNotifications)
+			notifications.POST("/read-all", writeTasks, notificationHandler.MarkAllRead)
+			notifications.POST("/:id/read", writeTasks, notificationHandler.MarkRead)
+		}
+
+		projects := v1.Group("/projects")
+		projects.Use(middleware.AuthRequired(), middleware.WorkspaceRequired())
+		{
+			projects.POST("", writeTasks, createContent, projectHandler.CreateProject)
+			projects.GET("", readTasks, projectHandler.ListProjects)
+			projects.GET("/:id", readTasks, projectHandler.GetProject)
+			projects.PATCH("/:id", writeTasks, createContent, projectHandler.UpdateProject)
+			projects.DELETE("/:id", writeTasks, createContent, projectHandler.DeleteProject)
+			projects.POST("/:id/archive", writeTasks, createContent, projectHandler.ArchiveProject)
+			projects.POST("/:id/unarchive", writeTasks, createContent, projectHandler.UnarchiveProject)
+			projects.GET("/:id/tasks", readTasks, taskHandler.GetProjectTasks)
+			projects.GET("/:id/workflow", readTasks, workflowHandler.GetProjectWorkflow)
+			projects.PUT("/:id/workflow", writeTasks, createContent, workflowHandler.ReplaceProjectWorkflow)
+			projects.DELETE("/:id/workflow", writeTasks, createContent, workflowHandler.ResetProjectWorkflow)
+		}
+
+		manageWorkspace := middleware.RequireWorkspaceRole(models.WorkspaceRoleOwner, models.WorkspaceRoleAdmin)
+
+		workflow := v1.Group("/workflow")
+		workflow.Use(middleware.AuthRequired(), middleware.WorkspaceRequired())
+		{
+			workflow.GET("", readTasks, workflowHandler.GetWorkspaceWorkflow)
+			workflow.PUT("", adminWorkspaces, manageWorkspace, workflowHandler.ReplaceWorkspaceWorkflow)
+			workflow.DELETE("", adminWorkspaces, manageWorkspace, workflowHandler.ResetWorkspaceWorkflow)
+		}
+
+		labels := v1.Group("/labels")
+		labels.Use(middleware.AuthRequired())
+		{
+			labels.POST("", writeTasks, labelHandler.CreateLabel)
+			labels.GET("", readTasks, labelHandler.ListLabels)
+			labels.GET("/:id", readTasks, labelHandler.GetLabel)
+			labels.PATCH("/:id", writeTasks, labelHandler.UpdateLabel)
+			labels.DELETE("/:id", writeTasks, labelHandler.DeleteLabel)
 		}
 
 		admin := v1.Group("/admin")
-		admin.Use(middleware.AuthRequired())
+		admin.Use(middleware.AuthRequired(), middleware.RequireScope(models.ScopeAdmin))
 		{
-			admin.GET("/tasks", taskHandler.GetAllTasks)
+			admin.GET("/tasks", middleware.RequireRole(models.UserRoleAdmin, models.UserRoleSupport), adminTaskHandler.GetAllTasks)
+			admin.GET("/users", middleware.RequireRole(models.UserRoleAdmin, models.UserRoleSupport), userHandler.ListUsers)
+			admin.PUT("/users/:id/role", middleware.RequireRole(models.UserRoleAdmin), userHandler.UpdateUserRole)
+			admin.POST("/users/:id/unlock", middleware.RequireRole(models.UserRoleAdmin, models.UserRoleSupport), userHandler.UnlockUser)
 		}
 	}
 
@@ -87,6 +295,10 @@ func main() {
 		Handler: r,
 	}
 
+	if reminderSchedulerConfig.Enabled {
+		reminderScheduler.Start()
+	}
+
 	go func() {
 		log.Printf("Server starting on port %s", port)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -106,5 +318,9 @@ func main() {
 		log.Fatal("Server forced to shutdown:", err)
 	}
 
+	if err := reminderScheduler.Shutdown(ctx); err != nil {
+		log.Println("Reminder scheduler did not stop cleanly:", err)
+	}
+
 	log.Println("Server exited")
 }
\ No newline at end of file
@@ -1,20 +1,30 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
+	"task-api/models"
 	"task-api/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
 const (
-	UserIDKey    = "user_id"
-	UserEmailKey = "user_email"
-	UserClaimsKey = "user_claims"
+	UserIDKey           = "user_id"
+	UserEmailKey        = "user_email"
+	UserClaimsKey       = "user_claims"
+	UserRoleKey         = "user_role"
+	AuthMethodKey       = "auth_method"
+	TokenScopesKey      = "token_scopes"
 	AuthorizationHeader = "Authorization"
-	BearerPrefix = "Bearer "
+	BearerPrefix        = "Bearer "
+)
+
+const (
+	AuthMethodSession             = "session"
+	AuthMethodPersonalAccessToken = "personal_access_token"
 )
 
 type ErrorResponse struct {
@@ -22,6 +32,29 @@ type ErrorResponse struct {
 	Message string `json:"message"`
 }
 
+type PersonalAccessTokenAuthenticator interface {
+	AuthenticatePersonalAccessToken(token string) (*utils.JWTClaims, []string, error)
+}
+
+type SessionRevocationChecker interface {
+	IsSessionRevoked(sessionID string) (bool, error)
+}
+
+var ErrSessionRevoked = errors.New("session has been revoked")
+
+var (
+	personalAccessTokens PersonalAccessTokenAuthenticator
+	sessionDenylist      SessionRevocationChecker
+)
+
+func UsePersonalAccessTokens(authenticator PersonalAccessTokenAuthenticator) {
+	personalAccessTokens = authenticator
+}
+
+func UseSessionDenylist(checker SessionRevocationChecker) {
+	sessionDenylist = checker
+}
+
 func AuthRequired() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
 		token := extractTokenFromHeader(c)
@@ -34,7 +67,7 @@ func AuthRequired() gin.HandlerFunc {
 			return
 		}
 
-		claims, err := utils.ValidateAccessToken(token)
+		claims, method, scopes, err := authenticate(token)
 		if err != nil {
 			var message string
 			switch err {
@@ -46,6 +79,8 @@ func AuthRequired() gin.HandlerFunc {
 				message = "Invalid token"
 			case utils.ErrMissingSecretKey:
 				message = "Authentication service unavailable"
+			case ErrSessionRevoked:
+				message = "Session has been revoked"
 			default:
 				message = "Authentication failed"
 			}
@@ -58,9 +93,7 @@ func AuthRequired() gin.HandlerFunc {
 			return
 		}
 
-		c.Set(UserIDKey, claims.UserID)
-		c.Set(UserEmailKey, claims.Email)
-		c.Set(UserClaimsKey, claims)
+		setAuthContext(c, claims, method, scopes)
 
 		c.Next()
 	})
@@ -74,17 +107,112 @@ func AuthOptional() gin.HandlerFunc {
 			return
 		}
 
-		claims, err := utils.ValidateAccessToken(token)
+		claims, method, scopes, err := authenticate(token)
 		if err == nil {
-			c.Set(UserIDKey, claims.UserID)
-			c.Set(UserEmailKey, claims.Email)
-			c.Set(UserClaimsKey, claims)
+			setAuthContext(c, claims, method, scopes)
+		}
+
+		c.Next()
+	})
+}
+
+func RequireRole(roles ...models.UserRole) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		role, exists := GetUserRole(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:   "unauthorized",
+				Message: "User authentication required",
+			})
+			c.Abort()
+			return
+		}
+
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "forbidden",
+			Message: "You don't have permission to access this resource",
+		})
+		c.Abort()
+	})
+}
+
+func RequireScope(scope string) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if GetAuthMethod(c) != AuthMethodPersonalAccessToken {
+			c.Next()
+			return
+		}
+
+		for _, granted := range GetTokenScopes(c) {
+			if granted == scope {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "insufficient_scope",
+			Message: "Token is missing the required scope: " + scope,
+		})
+		c.Abort()
+	})
+}
+
+func SessionOnly() gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if GetAuthMethod(c) == AuthMethodPersonalAccessToken {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "forbidden",
+				Message: "Personal access tokens cannot be used for this endpoint",
+			})
+			c.Abort()
+			return
 		}
 
 		c.Next()
 	})
 }
 
+func authenticate(token string) (*utils.JWTClaims, string, []string, error) {
+	if strings.HasPrefix(token, utils.PersonalAccessTokenPrefix) && personalAccessTokens != nil {
+		claims, scopes, err := personalAccessTokens.AuthenticatePersonalAccessToken(token)
+		return claims, AuthMethodPersonalAccessToken, scopes, err
+	}
+
+	claims, err := utils.ValidateAccessToken(token)
+	if err != nil {
+		return nil, AuthMethodSession, nil, err
+	}
+
+	if claims.SessionID != "" && sessionDenylist != nil {
+		revoked, err := sessionDenylist.IsSessionRevoked(claims.SessionID)
+		if err != nil {
+			return nil, AuthMethodSession, nil, err
+		}
+		if revoked {
+			return nil, AuthMethodSession, nil, ErrSessionRevoked
+		}
+	}
+
+	return claims, AuthMethodSession, nil, nil
+}
+
+func setAuthContext(c *gin.Context, claims *utils.JWTClaims, method string, scopes []string) {
+	c.Set(UserIDKey, claims.UserID)
+	c.Set(UserEmailKey, claims.Email)
+	c.Set(UserRoleKey, claims.Role)
+	c.Set(UserClaimsKey, claims)
+	c.Set(AuthMethodKey, method)
+	c.Set(TokenScopesKey, scopes)
+}
+
 func extractTokenFromHeader(c *gin.Context) string {
 	authHeader := c.GetHeader(AuthorizationHeader)
 	if authHeader == "" {
@@ -118,6 +246,24 @@ func GetUserEmail(c *gin.Context) (string, bool) {
 	return emailStr, ok
 }
 
+func GetUserRole(c *gin.Context) (models.UserRole, bool) {
+	role, exists := c.Get(UserRoleKey)
+	if !exists {
+		return "", false
+	}
+
+	roleStr, ok := role.(string)
+	return models.UserRole(roleStr), ok
+}
+
+func GetAuthMethod(c *gin.Context) string {
+	return c.GetString(AuthMethodKey)
+}
+
+func GetTokenScopes(c *gin.Context) []string {
+	return c.GetStringSlice(TokenScopesKey)
+}
+
 func GetUserClaims(c *gin.Context) (*utils.JWTClaims, bool) {
 	claims, exists := c.Get(UserClaimsKey)
 	if !exists {
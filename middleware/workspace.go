@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"task-api/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	WorkspaceIDKey   = "workspace_id"
+	WorkspaceRoleKey = "workspace_role"
+	WorkspaceHeader  = "X-Workspace-ID"
+)
+
+// WorkspaceResolver returns the caller's membership of a workspace, or of
+// their personal workspace when workspaceID is 0. A nil membership without an
+// error means the caller is not a member.
+type WorkspaceResolver interface {
+	ResolveWorkspace(userID, workspaceID uint) (*models.WorkspaceMember, error)
+}
+
+var workspaces WorkspaceResolver
+
+func UseWorkspaces(resolver WorkspaceResolver) {
+	workspaces = resolver
+}
+
+func WorkspaceRequired() gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		userID := RequireUserID(c)
+		if userID == 0 {
+			return
+		}
+
+		var workspaceID uint
+		if header := c.GetHeader(WorkspaceHeader); header != "" {
+			id, err := strconv.ParseUint(header, 10, 32)
+			if err != nil || id == 0 {
+				c.JSON(http.StatusBadRequest, ErrorResponse{
+					Error:   "invalid_workspace_id",
+					Message: "The " + WorkspaceHeader + " header must be a workspace ID",
+				})
+				c.Abort()
+				return
+			}
+			workspaceID = uint(id)
+		}
+
+		if workspaces == nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Workspaces are not configured",
+			})
+			c.Abort()
+			return
+		}
+
+		membership, err := workspaces.ResolveWorkspace(userID, workspaceID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to resolve workspace",
+			})
+			c.Abort()
+			return
+		}
+		if membership == nil {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "workspace_not_found",
+				Message: "Workspace not found",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set(WorkspaceIDKey, membership.WorkspaceID)
+		c.Set(WorkspaceRoleKey, membership.Role)
+		c.Header(WorkspaceHeader, strconv.FormatUint(uint64(membership.WorkspaceID), 10))
+
+		c.Next()
+	})
+}
+
+func RequireWorkspaceRole(roles ...models.WorkspaceRole) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		role, exists := GetWorkspaceRole(c)
+		if !exists {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "forbidden",
+				Message: "An active workspace is required",
+			})
+			c.Abort()
+			return
+		}
+
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "workspace_role_insufficient",
+			Message: "Your workspace role does not allow this",
+		})
+		c.Abort()
+	})
+}
+
+func GetWorkspaceID(c *gin.Context) uint {
+	return c.GetUint(WorkspaceIDKey)
+}
+
+func GetWorkspaceRole(c *gin.Context) (models.WorkspaceRole, bool) {
+	role, exists := c.Get(WorkspaceRoleKey)
+	if !exists {
+		return "", false
+	}
+
+	workspaceRole, ok := role.(models.WorkspaceRole)
+	return workspaceRole, ok
+}
\ No newline at end of file
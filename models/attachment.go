@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+type Attachment struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	TaskID      uint      `gorm:"not null;index" json:"task_id"`
+	Task        Task      `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"-"`
+	UserID      uint      `gorm:"not null;index" json:"user_id"`
+	Filename    string    `gorm:"type:varchar(255);not null" json:"filename"`
+	ContentType string    `gorm:"type:varchar(127);not null" json:"content_type"`
+	Size        int64     `gorm:"not null" json:"size"`
+	SHA256      string    `gorm:"column:sha256;type:varchar(64);not null" json:"sha256"`
+	StorageKey  string    `gorm:"type:varchar(512);uniqueIndex;not null" json:"-"`
+	Pending     bool      `gorm:"not null;default:false" json:"-"`
+}
\ No newline at end of file
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Comment struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `gorm:"index:idx_comments_task_created,priority:2" json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	TaskID    uint           `gorm:"not null;index:idx_comments_task_created,priority:1" json:"task_id"`
+	Task      Task           `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"-"`
+	UserID    uint           `gorm:"not null;index" json:"user_id"`
+	User      User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Body      string         `gorm:"type:text;not null" json:"body"`
+	EditedAt  *time.Time     `json:"edited_at,omitempty"`
+}
+
+func (c *Comment) IsEdited() bool {
+	return c.EditedAt != nil
+}
\ No newline at end of file
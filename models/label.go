@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+const DefaultLabelColor = "#6b7280"
+
+type Label struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_labels_user_name" json:"user_id"`
+	Name      string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_labels_user_name" json:"name"`
+	Color     string    `gorm:"type:varchar(7);not null;default:'#6b7280'" json:"color"`
+}
\ No newline at end of file
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+type LoginAttempt struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	Identifier     string     `gorm:"type:varchar(128);uniqueIndex;not null" json:"identifier"`
+	FailedAttempts int        `gorm:"not null;default:0" json:"failed_attempts"`
+	LastFailedAt   *time.Time `json:"last_failed_at,omitempty"`
+	LockedUntil    *time.Time `json:"locked_until,omitempty"`
+}
+
+func (a *LoginAttempt) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
\ No newline at end of file
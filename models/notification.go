@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+type Notification struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UserID     uint       `gorm:"not null;index:idx_notifications_user_read,priority:1" json:"user_id"`
+	TaskID     *uint      `gorm:"index" json:"task_id,omitempty"`
+	ReminderID *uint      `gorm:"uniqueIndex" json:"reminder_id,omitempty"`
+	Title      string     `gorm:"type:varchar(255);not null" json:"title"`
+	Body       string     `gorm:"type:text" json:"body"`
+	ReadAt     *time.Time `gorm:"index:idx_notifications_user_read,priority:2" json:"read_at,omitempty"`
+}
+
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
\ No newline at end of file
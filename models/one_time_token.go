@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+type OneTimeTokenPurpose string
+
+const (
+	OneTimeTokenPasswordReset     OneTimeTokenPurpose = "password_reset"
+	OneTimeTokenEmailVerification OneTimeTokenPurpose = "email_verification"
+)
+
+type OneTimeToken struct {
+	ID        uint                `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time           `json:"created_at"`
+	UserID    uint                `gorm:"not null;index" json:"user_id"`
+	Purpose   OneTimeTokenPurpose `gorm:"type:varchar(32);not null;index" json:"purpose"`
+	TokenHash string              `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time           `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time          `json:"used_at,omitempty"`
+}
+
+func (t *OneTimeToken) IsUsable() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
+}
\ No newline at end of file
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const DefaultProjectColor = "#3b82f6"
+
+type Project struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	UserID      uint           `gorm:"not null;index" json:"user_id"`
+	WorkspaceID uint           `gorm:"index" json:"workspace_id"`
+	Name        string         `gorm:"type:varchar(100);not null" json:"name"`
+	Description string         `gorm:"type:text" json:"description"`
+	Color       string         `gorm:"type:varchar(7);not null;default:'#3b82f6'" json:"color"`
+	Archived    bool           `gorm:"not null;default:false" json:"archived"`
+	SortOrder   int            `gorm:"not null;default:0" json:"sort_order"`
+}
\ No newline at end of file
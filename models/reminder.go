@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+)
+
+type ReminderChannel string
+
+const (
+	ReminderChannelEmail   ReminderChannel = "email"
+	ReminderChannelWebhook ReminderChannel = "webhook"
+	ReminderChannelInApp   ReminderChannel = "in_app"
+)
+
+type ReminderStatus string
+
+const (
+	ReminderStatusPending   ReminderStatus = "pending"
+	ReminderStatusSent      ReminderStatus = "sent"
+	ReminderStatusFailed    ReminderStatus = "failed"
+	ReminderStatusCancelled ReminderStatus = "cancelled"
+)
+
+type Reminder struct {
+	ID             uint            `gorm:"primaryKey" json:"id"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	TaskID         uint            `gorm:"not null;index" json:"task_id"`
+	Task           Task            `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"-"`
+	UserID         uint            `gorm:"not null;index" json:"user_id"`
+	Channel        ReminderChannel `gorm:"type:varchar(20);not null" json:"channel"`
+	WebhookURL     string          `gorm:"type:varchar(2048)" json:"webhook_url,omitempty"`
+	OffsetMinutes  *int            `json:"offset_minutes,omitempty"`
+	RemindAt       time.Time       `gorm:"not null;index:idx_reminders_due,priority:2" json:"remind_at"`
+	Status         ReminderStatus  `gorm:"type:varchar(20);not null;default:'pending';index:idx_reminders_due,priority:1" json:"status"`
+	Attempts       int             `gorm:"not null;default:0" json:"attempts"`
+	LastError      string          `gorm:"type:varchar(512)" json:"last_error,omitempty"`
+	LeaseOwner     string          `gorm:"type:varchar(64)" json:"-"`
+	LeaseExpiresAt *time.Time      `json:"-"`
+	SentAt         *time.Time      `json:"sent_at,omitempty"`
+}
+
+func (r *Reminder) IsRelative() bool {
+	return r.OffsetMinutes != nil
+}
\ No newline at end of file
@@ -24,16 +24,42 @@ const (
 )
 
 type Task struct {
-	ID          uint           `gorm:"primaryKey" json:"id"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
-	Title       string         `gorm:"not null" json:"title" binding:"required"`
-	Description string         `gorm:"type:text" json:"description"`
-	Status      TaskStatus     `gorm:"type:varchar(20);default:'pending'" json:"status"`
-	Priority    TaskPriority   `gorm:"type:varchar(20);default:'medium'" json:"priority"`
-	DueDate     *time.Time     `json:"due_date,omitempty"`
-	CompletedAt *time.Time     `json:"completed_at,omitempty"`
-	UserID      uint           `gorm:"not null;index" json:"user_id"`
-	User        User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	ID             uint               `gorm:"primaryKey" json:"id"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt     `gorm:"index" json:"-"`
+	Title          string             `gorm:"not null" json:"title" binding:"required"`
+	Description    string             `gorm:"type:text" json:"description"`
+	Status         TaskStatus         `gorm:"type:varchar(20);default:'pending'" json:"status"`
+	StatusCategory TaskStatusCategory `gorm:"type:varchar(20);not null;default:'todo';index" json:"status_category"`
+	Priority       TaskPriority       `gorm:"type:varchar(20);default:'medium'" json:"priority"`
+	DueDate        *time.Time         `json:"due_date,omitempty"`
+	CompletedAt    *time.Time         `json:"completed_at,omitempty"`
+	UserID         uint               `gorm:"not null;index" json:"user_id"`
+	User           User               `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	WorkspaceID    uint               `gorm:"index" json:"workspace_id"`
+	AssigneeID     *uint              `gorm:"index" json:"assignee_id,omitempty"`
+	Assignee       *User              `gorm:"foreignKey:AssigneeID" json:"assignee,omitempty"`
+	ParentID       *uint              `gorm:"index" json:"parent_id,omitempty"`
+	ProjectID      *uint              `gorm:"index" json:"project_id,omitempty"`
+	Project        *Project           `gorm:"foreignKey:ProjectID" json:"project,omitempty"`
+	Labels         []Label            `gorm:"many2many:task_labels;" json:"labels,omitempty"`
+
+	RecurrenceRule     string `gorm:"type:varchar(255)" json:"recurrence_rule,omitempty"`
+	RecurrenceTimezone string `gorm:"type:varchar(64)" json:"recurrence_timezone,omitempty"`
+	RecurrenceSeriesID *uint  `gorm:"index" json:"recurrence_series_id,omitempty"`
+	RecurrenceIndex    int    `gorm:"not null;default:0" json:"recurrence_index"`
+
+	SearchVector  string  `gorm:"->:false;type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('english', coalesce(title, '')), 'A') || setweight(to_tsvector('english', coalesce(description, '')), 'B')) STORED;index:idx_tasks_search_vector,type:gin" json:"-"`
+	SearchRank    float64 `gorm:"->;-:migration" json:"-"`
+	SearchTitle   string  `gorm:"->;-:migration" json:"-"`
+	SearchSnippet string  `gorm:"->;-:migration" json:"-"`
+}
+
+func (t *Task) IsClosed() bool {
+	return t.StatusCategory.IsClosed()
+}
+
+func (t *Task) IsRecurring() bool {
+	return t.RecurrenceRule != ""
 }
\ No newline at end of file
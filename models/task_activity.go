@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+type TaskActivityAction string
+
+const (
+	TaskActivityCreated   TaskActivityAction = "created"
+	TaskActivityUpdated   TaskActivityAction = "updated"
+	TaskActivityCompleted TaskActivityAction = "completed"
+	TaskActivityDeleted   TaskActivityAction = "deleted"
+)
+
+type TaskFieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// TaskActivity is an append-only entry in a task's history. Changes holds the
+// JSON-encoded []TaskFieldChange of the fields the action changed.
+type TaskActivity struct {
+	ID        uint               `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time          `gorm:"index:idx_task_activities_task_created,priority:2" json:"created_at"`
+	TaskID    uint               `gorm:"not null;index:idx_task_activities_task_created,priority:1" json:"task_id"`
+	ActorID   uint               `gorm:"not null;index" json:"actor_id"`
+	Actor     User               `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+	Action    TaskActivityAction `gorm:"type:varchar(20);not null" json:"action"`
+	Changes   string             `gorm:"type:jsonb;not null;default:'[]'" json:"-"`
+}
\ No newline at end of file
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+)
+
+type TaskDependency struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	BlockerID uint      `gorm:"not null;uniqueIndex:idx_task_dependencies_pair;index" json:"blocker_id"`
+	BlockedID uint      `gorm:"not null;uniqueIndex:idx_task_dependencies_pair" json:"blocked_id"`
+	Blocker   Task      `gorm:"foreignKey:BlockerID;constraint:OnDelete:CASCADE" json:"-"`
+	Blocked   Task      `gorm:"foreignKey:BlockedID;constraint:OnDelete:CASCADE" json:"-"`
+}
\ No newline at end of file
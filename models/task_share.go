@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+type TaskSharePermission string
+
+const (
+	TaskSharePermissionViewer TaskSharePermission = "viewer"
+	TaskSharePermissionEditor TaskSharePermission = "editor"
+)
+
+type TaskShare struct {
+	ID         uint                `gorm:"primaryKey" json:"id"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+	TaskID     uint                `gorm:"not null;uniqueIndex:idx_task_shares_task_user" json:"task_id"`
+	Task       Task                `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"-"`
+	UserID     uint                `gorm:"not null;uniqueIndex:idx_task_shares_task_user;index" json:"user_id"`
+	User       User                `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Permission TaskSharePermission `gorm:"type:varchar(20);not null" json:"permission"`
+}
\ No newline at end of file
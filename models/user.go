@@ -6,15 +6,44 @@ import (
 	"gorm.io/gorm"
 )
 
+type UserRole string
+
+const (
+	UserRoleUser    UserRole = "user"
+	UserRoleAdmin   UserRole = "admin"
+	UserRoleSupport UserRole = "support"
+)
+
+func (r UserRole) IsValid() bool {
+	switch r {
+	case UserRoleUser, UserRoleAdmin, UserRoleSupport:
+		return true
+	}
+	return false
+}
+
 type User struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
-	Email     string         `gorm:"uniqueIndex;not null" json:"email" binding:"required,email"`
-	Password  string         `gorm:"not null" json:"-"`
-	FirstName string         `gorm:"not null" json:"first_name" binding:"required"`
-	LastName  string         `gorm:"not null" json:"last_name" binding:"required"`
-	IsActive  bool           `gorm:"default:true" json:"is_active"`
-	Tasks     []Task         `gorm:"foreignKey:UserID" json:"tasks,omitempty"`
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	Email           string         `gorm:"uniqueIndex;not null" json:"email" binding:"required,email"`
+	Password        string         `gorm:"not null" json:"-"`
+	FirstName       string         `gorm:"not null" json:"first_name" binding:"required"`
+	LastName        string         `gorm:"not null" json:"last_name" binding:"required"`
+	Role            UserRole       `gorm:"type:varchar(20);not null;default:'user'" json:"role"`
+	IsActive        bool           `gorm:"default:true" json:"is_active"`
+	EmailVerifiedAt *time.Time     `json:"email_verified_at,omitempty"`
+	TOTPSecret      string         `gorm:"type:varchar(255)" json:"-"`
+	TOTPEnabledAt   *time.Time     `json:"-"`
+	TOTPLastStep    int64          `gorm:"default:0" json:"-"`
+	Tasks           []Task         `gorm:"foreignKey:UserID" json:"tasks,omitempty"`
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+func (u *User) IsTOTPEnabled() bool {
+	return u.TOTPEnabledAt != nil
 }
\ No newline at end of file
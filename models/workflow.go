@@ -0,0 +1,53 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+type TaskStatusCategory string
+
+const (
+	TaskStatusCategoryTodo       TaskStatusCategory = "todo"
+	TaskStatusCategoryInProgress TaskStatusCategory = "in_progress"
+	TaskStatusCategoryDone       TaskStatusCategory = "done"
+	TaskStatusCategoryCancelled  TaskStatusCategory = "cancelled"
+)
+
+var TaskStatusCategories = []TaskStatusCategory{TaskStatusCategoryTodo, TaskStatusCategoryInProgress, TaskStatusCategoryDone, TaskStatusCategoryCancelled}
+
+func (c TaskStatusCategory) IsClosed() bool {
+	return c == TaskStatusCategoryDone || c == TaskStatusCategoryCancelled
+}
+
+// WorkflowStatus is one status of a workspace's workflow, or of a project's
+// when ProjectID is set. Transitions holds the keys it may move to, separated
+// by commas.
+type WorkflowStatus struct {
+	ID          uint               `gorm:"primaryKey" json:"id"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	WorkspaceID uint               `gorm:"not null;index" json:"workspace_id"`
+	Workspace   Workspace          `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE" json:"-"`
+	ProjectID   *uint              `gorm:"index" json:"project_id,omitempty"`
+	Key         TaskStatus         `gorm:"type:varchar(20);not null" json:"key"`
+	Name        string             `gorm:"type:varchar(50);not null" json:"name"`
+	Category    TaskStatusCategory `gorm:"type:varchar(20);not null" json:"category"`
+	Position    int                `gorm:"not null;default:0" json:"position"`
+	Transitions string             `gorm:"type:varchar(500);not null;default:''" json:"-"`
+}
+
+func (WorkflowStatus) workspaceScoped() {}
+
+func (s *WorkflowStatus) TransitionList() []TaskStatus {
+	if s.Transitions == "" {
+		return []TaskStatus{}
+	}
+
+	keys := strings.Split(s.Transitions, ",")
+	transitions := make([]TaskStatus, len(keys))
+	for i, key := range keys {
+		transitions[i] = TaskStatus(key)
+	}
+	return transitions
+}
\ No newline at end of file
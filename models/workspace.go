@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WorkspaceRole string
+
+const (
+	WorkspaceRoleOwner  WorkspaceRole = "owner"
+	WorkspaceRoleAdmin  WorkspaceRole = "admin"
+	WorkspaceRoleMember WorkspaceRole = "member"
+	WorkspaceRoleGuest  WorkspaceRole = "guest"
+)
+
+// WorkspaceScoped is implemented by models whose rows belong to a workspace.
+// Queries on them are constrained to the active workspace by the repositories
+// package, so it cannot be implemented outside this package.
+type WorkspaceScoped interface {
+	workspaceScoped()
+}
+
+func (Task) workspaceScoped()    {}
+func (Project) workspaceScoped() {}
+
+type Workspace struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	Name      string         `gorm:"type:varchar(100);not null" json:"name"`
+	Personal  bool           `gorm:"not null;default:false" json:"personal"`
+	OwnerID   uint           `gorm:"not null;index;uniqueIndex:idx_workspaces_personal_owner,where:personal" json:"owner_id"`
+}
+
+type WorkspaceMember struct {
+	ID          uint          `gorm:"primaryKey" json:"id"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	WorkspaceID uint          `gorm:"not null;uniqueIndex:idx_workspace_members_workspace_user" json:"workspace_id"`
+	Workspace   Workspace     `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE" json:"workspace,omitempty"`
+	UserID      uint          `gorm:"not null;uniqueIndex:idx_workspace_members_workspace_user;index" json:"user_id"`
+	User        User          `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Role        WorkspaceRole `gorm:"type:varchar(20);not null" json:"role"`
+}
+
+type WorkspaceInvitation struct {
+	ID          uint          `gorm:"primaryKey" json:"id"`
+	CreatedAt   time.Time     `json:"created_at"`
+	WorkspaceID uint          `gorm:"not null;index" json:"workspace_id"`
+	Workspace   Workspace     `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE" json:"workspace,omitempty"`
+	Email       string        `gorm:"type:varchar(255);not null" json:"email"`
+	Role        WorkspaceRole `gorm:"type:varchar(20);not null" json:"role"`
+	TokenHash   string        `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	InvitedByID uint          `gorm:"not null" json:"invited_by_id"`
+	ExpiresAt   time.Time     `gorm:"not null" json:"expires_at"`
+	AcceptedAt  *time.Time    `json:"accepted_at,omitempty"`
+}
+
+func (r WorkspaceRole) CanManageMembers() bool {
+	return r == WorkspaceRoleOwner || r == WorkspaceRoleAdmin
+}
+
+func (r WorkspaceRole) CanCreateContent() bool {
+	return r == WorkspaceRoleOwner || r == WorkspaceRoleAdmin || r == WorkspaceRoleMember
+}
+
+func (i *WorkspaceInvitation) IsPending() bool {
+	return i.AcceptedAt == nil && time.Now().Before(i.ExpiresAt)
+}
\ No newline at end of file
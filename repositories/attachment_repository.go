@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"errors"
+
+	"task-api/models"
+)
+
+var ErrAttachmentQuotaExceeded = errors.New("attachment quota exceeded")
+
+type AttachmentRepository interface {
+	Reserve(attachment *models.Attachment, quota int64) error
+	Complete(id uint, sha256 string) error
+	GetByID(id uint) (*models.Attachment, error)
+	ListByTaskID(taskID uint) ([]models.Attachment, error)
+	Delete(id uint) error
+}
\ No newline at end of file
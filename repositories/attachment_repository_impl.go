@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"time"
+
+	"task-api/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// attachmentReservationTTL is how long an unfinished upload keeps counting
+// against its user's quota.
+const attachmentReservationTTL = time.Hour
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{
+		db: db,
+	}
+}
+
+// Reserve stores attachment as pending if its size fits in the user's quota.
+// The user row is locked so that concurrent uploads are checked one by one.
+func (r *attachmentRepository) Reserve(attachment *models.Attachment, quota int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, attachment.UserID).Error; err != nil {
+			return err
+		}
+
+		var used int64
+		err := tx.Model(&models.Attachment{}).
+			Joins("JOIN tasks ON tasks.id = attachments.task_id AND tasks.deleted_at IS NULL").
+			Where("attachments.user_id = ?", attachment.UserID).
+			Where("NOT attachments.pending OR attachments.created_at > ?", time.Now().Add(-attachmentReservationTTL)).
+			Select("COALESCE(SUM(attachments.size), 0)").
+			Scan(&used).Error
+		if err != nil {
+			return err
+		}
+		if used+attachment.Size > quota {
+			return ErrAttachmentQuotaExceeded
+		}
+
+		attachment.Pending = true
+		return tx.Create(attachment).Error
+	})
+}
+
+func (r *attachmentRepository) Complete(id uint, sha256 string) error {
+	return r.db.Model(&models.Attachment{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"sha256":  sha256,
+			"pending": false,
+		}).Error
+}
+
+func (r *attachmentRepository) GetByID(id uint) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := r.db.Where("NOT pending").First(&attachment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) ListByTaskID(taskID uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	err := r.db.Where("task_id = ? AND NOT pending", taskID).
+		Order("created_at ASC, id ASC").
+		Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *attachmentRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Attachment{}, id).Error
+}
\ No newline at end of file
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"task-api/models"
+)
+
+type CommentRepository interface {
+	Create(comment *models.Comment) error
+	GetByID(id uint) (*models.Comment, error)
+	ListByTaskID(taskID uint, pagination PaginationParams) ([]models.Comment, PaginationResult, error)
+	CountByTaskIDs(taskIDs []uint) (map[uint]int64, error)
+	Update(comment *models.Comment) error
+	Delete(id uint) error
+}
\ No newline at end of file
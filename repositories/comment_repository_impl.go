@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"task-api/models"
+
+	"gorm.io/gorm"
+)
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{
+		db: db,
+	}
+}
+
+func (r *commentRepository) Create(comment *models.Comment) error {
+	return r.db.Create(comment).Error
+}
+
+func (r *commentRepository) GetByID(id uint) (*models.Comment, error) {
+	var comment models.Comment
+	err := r.db.Preload("User").First(&comment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *commentRepository) ListByTaskID(taskID uint, pagination PaginationParams) ([]models.Comment, PaginationResult, error) {
+	query := r.db.Model(&models.Comment{}).Where("task_id = ?", taskID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, PaginationResult{}, err
+	}
+
+	var comments []models.Comment
+	err := query.Preload("User").
+		Offset(pagination.GetOffset()).
+		Limit(pagination.PageSize).
+		Order("created_at ASC, id ASC").
+		Find(&comments).Error
+	if err != nil {
+		return nil, PaginationResult{}, err
+	}
+
+	return comments, NewPaginationResult(pagination.Page, pagination.PageSize, total), nil
+}
+
+func (r *commentRepository) CountByTaskIDs(taskIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(taskIDs))
+	if len(taskIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		TaskID uint
+		Count  int64
+	}
+	err := r.db.Model(&models.Comment{}).
+		Select("task_id, COUNT(*) AS count").
+		Where("task_id IN ?", taskIDs).
+		Group("task_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.TaskID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *commentRepository) Update(comment *models.Comment) error {
+	return r.db.Omit("User", "Task").Save(comment).Error
+}
+
+func (r *commentRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Comment{}, id).Error
+}
\ No newline at end of file
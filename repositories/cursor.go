@@ -0,0 +1,219 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type cursorKind int
+
+const (
+	cursorKindTime cursorKind = iota
+	cursorKindInt
+	cursorKindFloat
+	cursorKindString
+)
+
+type cursorKey struct {
+	Name       string
+	Expr       string
+	Vars       []interface{}
+	OrderExpr  string
+	Kind       cursorKind
+	Descending bool
+	Nullable   bool
+}
+
+type cursorPayload struct {
+	Signature string            `json:"s"`
+	Values    []json.RawMessage `json:"v"`
+}
+
+func cursorSignature(keys []cursorKey, extra string) string {
+	parts := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		if key.Descending {
+			parts = append(parts, "-"+key.Name)
+		} else {
+			parts = append(parts, key.Name)
+		}
+	}
+	if extra != "" {
+		parts = append(parts, extra)
+	}
+	return strings.Join(parts, ",")
+}
+
+func encodeCursor(signature string, values []interface{}) (string, error) {
+	payload := cursorPayload{
+		Signature: signature,
+		Values:    make([]json.RawMessage, len(values)),
+	}
+
+	for i, value := range values {
+		if t, ok := value.(*time.Time); ok {
+			if t == nil {
+				value = nil
+			} else {
+				value = t.UTC().Format(time.RFC3339Nano)
+			}
+		}
+		if t, ok := value.(time.Time); ok {
+			value = t.UTC().Format(time.RFC3339Nano)
+		}
+
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		payload.Values[i] = raw
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(cursor, signature string, keys []cursorKey) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if payload.Signature != signature || len(payload.Values) != len(keys) {
+		return nil, ErrInvalidCursor
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		raw := payload.Values[i]
+		if string(raw) == "null" {
+			if !key.Nullable {
+				return nil, ErrInvalidCursor
+			}
+			continue
+		}
+
+		value, err := decodeCursorValue(key.Kind, raw)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+func decodeCursorValue(kind cursorKind, raw json.RawMessage) (interface{}, error) {
+	switch kind {
+	case cursorKindTime:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	case cursorKindInt:
+		var n int64
+		err := json.Unmarshal(raw, &n)
+		return n, err
+	case cursorKindFloat:
+		var f float64
+		err := json.Unmarshal(raw, &f)
+		return f, err
+	case cursorKindString:
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	}
+	return nil, fmt.Errorf("unknown cursor kind %d", kind)
+}
+
+func keysetCondition(keys []cursorKey, values []interface{}, backward bool) (string, []interface{}) {
+	var clauses []string
+	var vars []interface{}
+	var equalSQL []string
+	var equalVars []interface{}
+
+	for i, key := range keys {
+		value := values[i]
+
+		var stepSQL string
+		var stepVars []interface{}
+		switch {
+		case value == nil && backward:
+			stepSQL = key.Expr + " IS NOT NULL"
+			stepVars = key.Vars
+		case value == nil:
+			stepSQL = ""
+		default:
+			operator := ">"
+			if key.Descending != backward {
+				operator = "<"
+			}
+			stepSQL = fmt.Sprintf("%s %s ?", key.Expr, operator)
+			stepVars = append(append([]interface{}{}, key.Vars...), value)
+			if key.Nullable && !backward {
+				stepSQL = fmt.Sprintf("(%s OR %s IS NULL)", stepSQL, key.Expr)
+				stepVars = append(stepVars, key.Vars...)
+			}
+		}
+
+		if stepSQL != "" {
+			clauses = append(clauses, "("+strings.Join(append(append([]string{}, equalSQL...), stepSQL), " AND ")+")")
+			vars = append(append(vars, equalVars...), stepVars...)
+		}
+
+		if value == nil {
+			equalSQL = append(equalSQL, key.Expr+" IS NULL")
+			equalVars = append(equalVars, key.Vars...)
+		} else {
+			equalSQL = append(equalSQL, key.Expr+" = ?")
+			equalVars = append(append(equalVars, key.Vars...), value)
+		}
+	}
+
+	if len(clauses) == 0 {
+		return "1 = 0", nil
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", vars
+}
+
+func keysetOrder(keys []cursorKey, backward bool) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		descending := key.Descending != backward
+
+		direction := "ASC"
+		if descending {
+			direction = "DESC"
+		}
+
+		expr := key.OrderExpr
+		if expr == "" {
+			expr = key.Expr
+		}
+
+		part := expr + " " + direction
+		if key.Nullable {
+			if backward {
+				part += " NULLS FIRST"
+			} else {
+				part += " NULLS LAST"
+			}
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, ", ")
+}
\ No newline at end of file
@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	testDueDateKey = cursorKey{Name: "due_date", Expr: "tasks.due_date", Kind: cursorKindTime, Nullable: true}
+	testTitleKey   = cursorKey{Name: "title", Expr: "tasks.title", Kind: cursorKindString, Descending: true}
+	testRankKey    = cursorKey{Name: "rank", Expr: "ts_rank(x, ?)", Vars: []interface{}{"q"}, Kind: cursorKindFloat, Descending: true}
+	testIDKey      = cursorKey{Name: "id", Expr: "tasks.id", Kind: cursorKindInt}
+)
+
+func rawCursor(json string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(json))
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	due := time.Date(2025, 3, 1, 9, 30, 0, 123456000, time.FixedZone("CET", 3600))
+	keys := []cursorKey{testDueDateKey, testTitleKey, testRankKey, testIDKey}
+	signature := cursorSignature(keys, "q=report")
+
+	tests := []struct {
+		name   string
+		values []interface{}
+		want   []interface{}
+	}{
+		{
+			name:   "all values set",
+			values: []interface{}{&due, "Report", 0.25, uint(42)},
+			want:   []interface{}{due.UTC(), "Report", 0.25, int64(42)},
+		},
+		{
+			name:   "nil time pointer",
+			values: []interface{}{(*time.Time)(nil), "", 0.0, uint(7)},
+			want:   []interface{}{nil, "", 0.0, int64(7)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := encodeCursor(signature, tt.values)
+			if err != nil {
+				t.Fatalf("encodeCursor: %v", err)
+			}
+
+			got, err := decodeCursor(cursor, signature, keys)
+			if err != nil {
+				t.Fatalf("decodeCursor: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeCursor = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejectsInvalidInput(t *testing.T) {
+	keys := []cursorKey{testDueDateKey, testIDKey}
+	signature := cursorSignature(keys, "")
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "!!!"},
+		{"not JSON", rawCursor("not json")},
+		{"other sort", rawCursor(`{"s":"-due_date,id","v":[null,1]}`)},
+		{"other search", rawCursor(`{"s":"due_date,id,q=x","v":[null,1]}`)},
+		{"too few values", rawCursor(`{"s":"due_date,id","v":[null]}`)},
+		{"too many values", rawCursor(`{"s":"due_date,id","v":[null,1,2]}`)},
+		{"null for a non-nullable key", rawCursor(`{"s":"due_date,id","v":[null,null]}`)},
+		{"string for an integer key", rawCursor(`{"s":"due_date,id","v":[null,"1"]}`)},
+		{"fractional integer", rawCursor(`{"s":"due_date,id","v":[null,1.5]}`)},
+		{"malformed time", rawCursor(`{"s":"due_date,id","v":["yesterday",1]}`)},
+		{"number for a time key", rawCursor(`{"s":"due_date,id","v":[1700000000,1]}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.cursor, signature, keys); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("decodeCursor error = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestKeysetCondition(t *testing.T) {
+	due := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		keys     []cursorKey
+		values   []interface{}
+		backward bool
+		wantSQL  string
+		wantVars []interface{}
+	}{
+		{
+			name:     "forward past a due date includes the null tail",
+			keys:     []cursorKey{testDueDateKey, testIDKey},
+			values:   []interface{}{due, int64(5)},
+			wantSQL:  "(((tasks.due_date > ? OR tasks.due_date IS NULL)) OR (tasks.due_date = ? AND tasks.id > ?))",
+			wantVars: []interface{}{due, due, int64(5)},
+		},
+		{
+			name:     "forward inside the null tail",
+			keys:     []cursorKey{testDueDateKey, testIDKey},
+			values:   []interface{}{nil, int64(5)},
+			wantSQL:  "((tasks.due_date IS NULL AND tasks.id > ?))",
+			wantVars: []interface{}{int64(5)},
+		},
+		{
+			name:     "backward before a due date",
+			keys:     []cursorKey{testDueDateKey, testIDKey},
+			values:   []interface{}{due, int64(5)},
+			backward: true,
+			wantSQL:  "((tasks.due_date < ?) OR (tasks.due_date = ? AND tasks.id < ?))",
+			wantVars: []interface{}{due, due, int64(5)},
+		},
+		{
+			name:     "backward from the null tail reaches dated tasks",
+			keys:     []cursorKey{testDueDateKey, testIDKey},
+			values:   []interface{}{nil, int64(5)},
+			backward: true,
+			wantSQL:  "((tasks.due_date IS NOT NULL) OR (tasks.due_date IS NULL AND tasks.id < ?))",
+			wantVars: []interface{}{int64(5)},
+		},
+		{
+			name:     "descending key flips the operator",
+			keys:     []cursorKey{testTitleKey, testIDKey},
+			values:   []interface{}{"b", int64(3)},
+			wantSQL:  "((tasks.title < ?) OR (tasks.title = ? AND tasks.id > ?))",
+			wantVars: []interface{}{"b", "b", int64(3)},
+		},
+		{
+			name:     "expression vars precede the cursor value",
+			keys:     []cursorKey{testRankKey, testIDKey},
+			values:   []interface{}{0.5, int64(3)},
+			wantSQL:  "((ts_rank(x, ?) < ?) OR (ts_rank(x, ?) = ? AND tasks.id > ?))",
+			wantVars: []interface{}{"q", 0.5, "q", 0.5, int64(3)},
+		},
+		{
+			name:     "forward past the last null matches nothing",
+			keys:     []cursorKey{testDueDateKey},
+			values:   []interface{}{nil},
+			wantSQL:  "1 = 0",
+			wantVars: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotVars := keysetCondition(tt.keys, tt.values, tt.backward)
+			if gotSQL != tt.wantSQL {
+				t.Errorf("keysetCondition SQL = %q, want %q", gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotVars, tt.wantVars) {
+				t.Errorf("keysetCondition vars = %#v, want %#v", gotVars, tt.wantVars)
+			}
+		})
+	}
+}
+
+func TestKeysetOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []cursorKey
+		backward bool
+		want     string
+	}{
+		{"forward puts nulls last", []cursorKey{testDueDateKey, testIDKey}, false, "tasks.due_date ASC NULLS LAST, tasks.id ASC"},
+		{"backward reverses every key", []cursorKey{testDueDateKey, testIDKey}, true, "tasks.due_date DESC NULLS FIRST, tasks.id DESC"},
+		{"descending key", []cursorKey{testTitleKey, testIDKey}, false, "tasks.title DESC, tasks.id ASC"},
+		{"order expression overrides the filter expression", []cursorKey{{Name: "p", Expr: "tasks.priority", OrderExpr: "priority_rank", Kind: cursorKindInt}}, false, "priority_rank ASC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keysetOrder(tt.keys, tt.backward); got != tt.want {
+				t.Errorf("keysetOrder = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
\ No newline at end of file
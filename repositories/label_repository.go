@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"task-api/models"
+)
+
+type LabelRepository interface {
+	Create(label *models.Label) error
+	GetByID(id uint) (*models.Label, error)
+	GetByName(userID uint, name string) (*models.Label, error)
+	ListByUserID(userID uint) ([]models.Label, error)
+	ListByIDs(userID uint, ids []uint) ([]models.Label, error)
+	ListByNames(userID uint, names []string) ([]models.Label, error)
+	Update(label *models.Label) error
+	Delete(id, actorID uint) error
+}
\ No newline at end of file
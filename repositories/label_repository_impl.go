@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"time"
+
+	"task-api/models"
+
+	"gorm.io/gorm"
+)
+
+const labelRemovalActivitySQL = `INSERT INTO task_activities (created_at, task_id, actor_id, action, changes)
+SELECT ?, tasks.id, ?, ?, jsonb_build_array(jsonb_build_object(
+	'field', 'labels',
+	'before', (SELECT jsonb_agg(tl.label_id ORDER BY tl.label_id) FROM task_labels tl WHERE tl.task_id = tasks.id),
+	'after', COALESCE((SELECT jsonb_agg(tl.label_id ORDER BY tl.label_id) FROM task_labels tl WHERE tl.task_id = tasks.id AND tl.label_id <> ?), '[]'::jsonb)
+))
+FROM tasks
+WHERE tasks.deleted_at IS NULL AND tasks.id IN (SELECT task_id FROM task_labels WHERE label_id = ?)`
+
+type labelRepository struct {
+	db *gorm.DB
+}
+
+func NewLabelRepository(db *gorm.DB) LabelRepository {
+	return &labelRepository{
+		db: db,
+	}
+}
+
+func (r *labelRepository) Create(label *models.Label) error {
+	return r.db.Create(label).Error
+}
+
+func (r *labelRepository) GetByID(id uint) (*models.Label, error) {
+	var label models.Label
+	err := r.db.First(&label, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+func (r *labelRepository) GetByName(userID uint, name string) (*models.Label, error) {
+	var label models.Label
+	err := r.db.Where("user_id = ? AND name = ?", userID, name).First(&label).Error
+	if err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+func (r *labelRepository) ListByUserID(userID uint) ([]models.Label, error) {
+	var labels []models.Label
+	err := r.db.Where("user_id = ?", userID).
+		Order("LOWER(name) ASC").
+		Find(&labels).Error
+	return labels, err
+}
+
+func (r *labelRepository) ListByIDs(userID uint, ids []uint) ([]models.Label, error) {
+	var labels []models.Label
+	err := r.db.Where("user_id = ? AND id IN ?", userID, ids).Find(&labels).Error
+	return labels, err
+}
+
+func (r *labelRepository) ListByNames(userID uint, names []string) ([]models.Label, error) {
+	var labels []models.Label
+	err := r.db.Where("user_id = ? AND name IN ?", userID, names).Find(&labels).Error
+	return labels, err
+}
+
+func (r *labelRepository) Update(label *models.Label) error {
+	return r.db.Save(label).Error
+}
+
+func (r *labelRepository) Delete(id, actorID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(labelRemovalActivitySQL, time.Now(), actorID, models.TaskActivityUpdated, id, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM task_labels WHERE label_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Label{}, id).Error
+	})
+}
\ No newline at end of file
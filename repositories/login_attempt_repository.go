@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"time"
+
+	"task-api/models"
+)
+
+type LoginAttemptRepository interface {
+	GetByIdentifier(identifier string) (*models.LoginAttempt, error)
+	RecordFailure(identifier string, at time.Time) (*models.LoginAttempt, error)
+	Lock(identifier string, until time.Time) error
+	Reset(identifier string) error
+}
\ No newline at end of file
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"time"
+
+	"task-api/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) LoginAttemptRepository {
+	return &loginAttemptRepository{
+		db: db,
+	}
+}
+
+func (r *loginAttemptRepository) GetByIdentifier(identifier string) (*models.LoginAttempt, error) {
+	var attempt models.LoginAttempt
+	err := r.db.Where("identifier = ?", identifier).First(&attempt).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (r *loginAttemptRepository) RecordFailure(identifier string, at time.Time) (*models.LoginAttempt, error) {
+	attempt := models.LoginAttempt{
+		Identifier:     identifier,
+		FailedAttempts: 1,
+		LastFailedAt:   &at,
+	}
+
+	err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "identifier"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"failed_attempts": gorm.Expr("login_attempts.failed_attempts + 1"),
+			"last_failed_at":  at,
+			"updated_at":      at,
+		}),
+	}).Create(&attempt).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByIdentifier(identifier)
+}
+
+func (r *loginAttemptRepository) Lock(identifier string, until time.Time) error {
+	return r.db.Model(&models.LoginAttempt{}).
+		Where("identifier = ?", identifier).
+		Update("locked_until", until).Error
+}
+
+func (r *loginAttemptRepository) Reset(identifier string) error {
+	return r.db.Where("identifier = ?", identifier).Delete(&models.LoginAttempt{}).Error
+}
\ No newline at end of file
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"time"
+
+	"task-api/models"
+)
+
+type NotificationRepository interface {
+	Create(notification *models.Notification) error
+	GetByID(id uint) (*models.Notification, error)
+	ListByUserID(userID uint, unreadOnly bool, pagination PaginationParams) ([]models.Notification, PaginationResult, error)
+	CountUnread(userID uint) (int64, error)
+	MarkRead(id uint, readAt time.Time) error
+	MarkAllRead(userID uint, readAt time.Time) error
+}
\ No newline at end of file
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"time"
+
+	"task-api/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+func (r *notificationRepository) Create(notification *models.Notification) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "reminder_id"}},
+		DoNothing: true,
+	}).Create(notification).Error
+}
+
+func (r *notificationRepository) GetByID(id uint) (*models.Notification, error) {
+	var notification models.Notification
+	err := r.db.First(&notification, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func (r *notificationRepository) ListByUserID(userID uint, unreadOnly bool, pagination PaginationParams) ([]models.Notification, PaginationResult, error) {
+	query := r.db.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, PaginationResult{}, err
+	}
+
+	var notifications []models.Notification
+	err := query.Offset(pagination.GetOffset()).
+		Limit(pagination.PageSize).
+		Order("created_at DESC, id DESC").
+		Find(&notifications).Error
+	if err != nil {
+		return nil, PaginationResult{}, err
+	}
+
+	return notifications, NewPaginationResult(pagination.Page, pagination.PageSize, total), nil
+}
+
+func (r *notificationRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *notificationRepository) MarkRead(id uint, readAt time.Time) error {
+	return r.db.Model(&models.Notification{}).
+		Where("id = ? AND read_at IS NULL", id).
+		Update("read_at", readAt).Error
+}
+
+func (r *notificationRepository) MarkAllRead(userID uint, readAt time.Time) error {
+	return r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt).Error
+}
\ No newline at end of file
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"task-api/models"
+)
+
+type OneTimeTokenRepository interface {
+	Create(token *models.OneTimeToken) error
+	GetByHash(purpose models.OneTimeTokenPurpose, tokenHash string) (*models.OneTimeToken, error)
+	MarkUsed(id uint) (bool, error)
+	ResetPassword(id, userID uint, passwordHash string) (bool, error)
+	InvalidateForUser(userID uint, purpose models.OneTimeTokenPurpose) error
+}
\ No newline at end of file
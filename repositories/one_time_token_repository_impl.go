@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"time"
+
+	"task-api/models"
+
+	"gorm.io/gorm"
+)
+
+type oneTimeTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewOneTimeTokenRepository(db *gorm.DB) OneTimeTokenRepository {
+	return &oneTimeTokenRepository{
+		db: db,
+	}
+}
+
+func (r *oneTimeTokenRepository) Create(token *models.OneTimeToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *oneTimeTokenRepository) GetByHash(purpose models.OneTimeTokenPurpose, tokenHash string) (*models.OneTimeToken, error) {
+	var token models.OneTimeToken
+	err := r.db.Where("purpose = ? AND token_hash = ?", purpose, tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *oneTimeTokenRepository) MarkUsed(id uint) (bool, error) {
+	result := r.db.Model(&models.OneTimeToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+// ResetPassword marks a password reset token used and sets the user's new
+// password hash in one transaction. It reports false, changing nothing, when
+// the token has already been used.
+func (r *oneTimeTokenRepository) ResetPassword(id, userID uint, passwordHash string) (bool, error) {
+	used := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.OneTimeToken{}).
+			Where("id = ? AND used_at IS NULL", id).
+			Update("used_at", time.Now())
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).Update("password", passwordHash).Error; err != nil {
+			return err
+		}
+		used = true
+		return nil
+	})
+	return used, err
+}
+
+func (r *oneTimeTokenRepository) InvalidateForUser(userID uint, purpose models.OneTimeTokenPurpose) error {
+	return r.db.Model(&models.OneTimeToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+}
\ No newline at end of file
@@ -1,15 +1,28 @@
 package repositories
 
+type PaginationMode string
+
+const (
+	PaginationModeOffset PaginationMode = "offset"
+	PaginationModeCursor PaginationMode = "cursor"
+)
+
 type PaginationParams struct {
-	Page     int `json:"page" form:"page"`
-	PageSize int `json:"page_size" form:"page_size"`
+	Page         int            `json:"page" form:"page"`
+	PageSize     int            `json:"page_size" form:"page_size"`
+	Mode         PaginationMode `json:"mode" form:"pagination"`
+	After        string         `json:"after" form:"after"`
+	Before       string         `json:"before" form:"before"`
+	IncludeTotal bool           `json:"include_total" form:"include_total"`
 }
 
 type PaginationResult struct {
-	Page       int   `json:"page"`
-	PageSize   int   `json:"page_size"`
-	Total      int64 `json:"total"`
-	TotalPages int   `json:"total_pages"`
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size"`
+	Total      *int64 `json:"total,omitempty"`
+	TotalPages *int   `json:"total_pages,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 func NewPaginationParams(page, pageSize int) PaginationParams {
@@ -20,21 +33,36 @@ func NewPaginationParams(page, pageSize int) PaginationParams {
 		pageSize = 10
 	}
 	return PaginationParams{
-		Page:     page,
-		PageSize: pageSize,
+		Page:         page,
+		PageSize:     pageSize,
+		Mode:         PaginationModeOffset,
+		IncludeTotal: true,
 	}
 }
 
+func NewCursorPaginationParams(after, before string, pageSize int, includeTotal bool) PaginationParams {
+	params := NewPaginationParams(1, pageSize)
+	params.Mode = PaginationModeCursor
+	params.After = after
+	params.Before = before
+	params.IncludeTotal = includeTotal
+	return params
+}
+
 func (p PaginationParams) GetOffset() int {
 	return (p.Page - 1) * p.PageSize
 }
 
+func (p PaginationParams) IsCursor() bool {
+	return p.Mode == PaginationModeCursor
+}
+
 func NewPaginationResult(page, pageSize int, total int64) PaginationResult {
 	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
 	return PaginationResult{
 		Page:       page,
 		PageSize:   pageSize,
-		Total:      total,
-		TotalPages: totalPages,
+		Total:      &total,
+		TotalPages: &totalPages,
 	}
 }
\ No newline at end of file
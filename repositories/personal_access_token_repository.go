@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"task-api/models"
+)
+
+type PersonalAccessTokenRepository interface {
+	Create(token *models.PersonalAccessToken) error
+	GetByID(id uint) (*models.PersonalAccessToken, error)
+	GetByHash(tokenHash string) (*models.PersonalAccessToken, error)
+	ListByUserID(userID uint) ([]models.PersonalAccessToken, error)
+	Update(token *models.PersonalAccessToken) error
+	TouchLastUsed(id uint) error
+}
\ No newline at end of file
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"task-api/models"
+)
+
+type ProjectRepository interface {
+	InWorkspace(workspaceID uint) ProjectRepository
+	Create(project *models.Project) error
+	GetByID(id uint) (*models.Project, error)
+	ListByUserID(userID uint, includeArchived bool) ([]models.Project, error)
+	NextSortOrder(userID uint) (int, error)
+	Update(project *models.Project) error
+	Delete(id, actorID uint) error
+}
\ No newline at end of file
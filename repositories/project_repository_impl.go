@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"task-api/models"
+
+	"gorm.io/gorm"
+)
+
+type projectRepository struct {
+	db *gorm.DB
+}
+
+func NewProjectRepository(db *gorm.DB) ProjectRepository {
+	return &projectRepository{
+		db: db,
+	}
+}
+
+func (r *projectRepository) InWorkspace(workspaceID uint) ProjectRepository {
+	return &projectRepository{
+		db: ScopeToWorkspace(r.db, workspaceID),
+	}
+}
+
+func (r *projectRepository) Create(project *models.Project) error {
+	return r.db.Create(project).Error
+}
+
+func (r *projectRepository) GetByID(id uint) (*models.Project, error) {
+	var project models.Project
+	err := r.db.First(&project, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (r *projectRepository) ListByUserID(userID uint, includeArchived bool) ([]models.Project, error) {
+	var projects []models.Project
+	query := r.db.Where("user_id = ?", userID)
+	if !includeArchived {
+		query = query.Where("archived = ?", false)
+	}
+	err := query.Order("sort_order ASC, id ASC").Find(&projects).Error
+	return projects, err
+}
+
+func (r *projectRepository) NextSortOrder(userID uint) (int, error) {
+	var maxOrder *int
+	err := r.db.Model(&models.Project{}).
+		Where("user_id = ?", userID).
+		Select("MAX(sort_order)").
+		Scan(&maxOrder).Error
+	if err != nil {
+		return 0, err
+	}
+	if maxOrder == nil {
+		return 0, nil
+	}
+	return *maxOrder + 1, nil
+}
+
+func (r *projectRepository) Update(project *models.Project) error {
+	return r.db.Save(project).Error
+}
+
+func (r *projectRepository) Delete(id, actorID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		changes := []models.TaskFieldChange{{Field: "project_id", Before: id, After: nil}}
+		if err := recordTaskActivities(tx, tx.Model(&models.Task{}).Where("tasks.project_id = ?", id), actorID, models.TaskActivityUpdated, changes); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Task{}).Where("project_id = ?", id).Update("project_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&models.WorkflowStatus{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Project{}, id).Error
+	})
+}
\ No newline at end of file
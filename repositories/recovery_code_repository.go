@@ -0,0 +1,7 @@
+package repositories
+
+type RecoveryCodeRepository interface {
+	ReplaceForUser(userID uint, codeHashes []string) error
+	Consume(userID uint, codeHash string) (bool, error)
+	DeleteForUser(userID uint) error
+}
\ No newline at end of file
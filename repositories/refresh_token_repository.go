@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"task-api/models"
+)
+
+type RefreshTokenRepository interface {
+	Create(token *models.RefreshToken) error
+	GetByHash(tokenHash string) (*models.RefreshToken, error)
+	Rotate(current *models.RefreshToken, next *models.RefreshToken) (bool, error)
+	RevokeFamily(familyID string) error
+	RevokeAllForUser(userID uint) error
+	RevokeAllForUserExcept(userID uint, familyID string) error
+}
\ No newline at end of file
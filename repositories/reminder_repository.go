@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"task-api/models"
+)
+
+// ErrReminderLeaseLost is returned when a reminder is no longer leased by the
+// caller, typically because the lease expired and another scheduler claimed it.
+var ErrReminderLeaseLost = errors.New("reminder lease lost")
+
+type ReminderRepository interface {
+	Create(reminder *models.Reminder) error
+	GetByID(id uint) (*models.Reminder, error)
+	ListByTaskID(taskID uint) ([]models.Reminder, error)
+	CountPendingByTaskID(taskID uint) (int64, error)
+	Delete(id uint) error
+	RescheduleRelative(taskID uint, dueDate time.Time) error
+	ClaimDue(owner string, now time.Time, lease time.Duration, limit int) ([]models.Reminder, error)
+	RenewLease(id uint, owner string, expiresAt time.Time) error
+	MarkSent(id uint, owner string, sentAt time.Time) error
+	MarkFailed(id uint, owner, lastError string, retryAt *time.Time) error
+	Cancel(id uint, owner string) error
+	ReleaseLeases(owner string) error
+}
\ No newline at end of file
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"time"
+
+	"task-api/models"
+
+	"gorm.io/gorm"
+)
+
+const maxReminderErrorLength = 512
+
+type reminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) ReminderRepository {
+	return &reminderRepository{
+		db: db,
+	}
+}
+
+func (r *reminderRepository) Create(reminder *models.Reminder) error {
+	return r.db.Create(reminder).Error
+}
+
+func (r *reminderRepository) GetByID(id uint) (*models.Reminder, error) {
+	var reminder models.Reminder
+	err := r.db.First(&reminder, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+func (r *reminderRepository) ListByTaskID(taskID uint) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+	err := r.db.Where("task_id = ?", taskID).
+		Order("remind_at ASC, id ASC").
+		Find(&reminders).Error
+	return reminders, err
+}
+
+func (r *reminderRepository) CountPendingByTaskID(taskID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Reminder{}).
+		Where("task_id = ? AND status = ?", taskID, models.ReminderStatusPending).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *reminderRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Reminder{}, id).Error
+}
+
+func (r *reminderRepository) RescheduleRelative(taskID uint, dueDate time.Time) error {
+	return r.db.Exec(`UPDATE reminders
+SET remind_at = CAST(? AS timestamptz) - make_interval(mins => offset_minutes),
+	status = ?, attempts = 0, last_error = '', sent_at = NULL, lease_owner = '', lease_expires_at = NULL, updated_at = ?
+WHERE task_id = ? AND offset_minutes IS NOT NULL AND status <> ?`,
+		dueDate, models.ReminderStatusPending, time.Now(), taskID, models.ReminderStatusCancelled).Error
+}
+
+func (r *reminderRepository) ClaimDue(owner string, now time.Time, lease time.Duration, limit int) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+	err := r.db.Raw(`UPDATE reminders
+SET lease_owner = ?, lease_expires_at = ?, updated_at = ?
+WHERE id IN (
+	SELECT id FROM reminders
+	WHERE status = ? AND remind_at <= ? AND (lease_expires_at IS NULL OR lease_expires_at <= ?)
+	ORDER BY remind_at ASC
+	LIMIT ?
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *`,
+		owner, now.Add(lease), now,
+		models.ReminderStatusPending, now, now, limit).
+		Scan(&reminders).Error
+	return reminders, err
+}
+
+func (r *reminderRepository) RenewLease(id uint, owner string, expiresAt time.Time) error {
+	return r.updateLeased(id, owner, map[string]interface{}{
+		"lease_expires_at": expiresAt,
+	})
+}
+
+func (r *reminderRepository) MarkSent(id uint, owner string, sentAt time.Time) error {
+	return r.updateLeased(id, owner, map[string]interface{}{
+		"status":           models.ReminderStatusSent,
+		"sent_at":          sentAt,
+		"attempts":         gorm.Expr("attempts + 1"),
+		"last_error":       "",
+		"lease_owner":      "",
+		"lease_expires_at": nil,
+	})
+}
+
+func (r *reminderRepository) MarkFailed(id uint, owner, lastError string, retryAt *time.Time) error {
+	if len(lastError) > maxReminderErrorLength {
+		lastError = lastError[:maxReminderErrorLength]
+	}
+
+	updates := map[string]interface{}{
+		"attempts":         gorm.Expr("attempts + 1"),
+		"last_error":       lastError,
+		"lease_owner":      "",
+		"lease_expires_at": retryAt,
+	}
+	if retryAt == nil {
+		updates["status"] = models.ReminderStatusFailed
+	}
+
+	return r.updateLeased(id, owner, updates)
+}
+
+func (r *reminderRepository) Cancel(id uint, owner string) error {
+	return r.updateLeased(id, owner, map[string]interface{}{
+		"status":           models.ReminderStatusCancelled,
+		"lease_owner":      "",
+		"lease_expires_at": nil,
+	})
+}
+
+func (r *reminderRepository) ReleaseLeases(owner string) error {
+	return r.db.Model(&models.Reminder{}).
+		Where("lease_owner = ? AND status = ?", owner, models.ReminderStatusPending).
+		Updates(map[string]interface{}{
+			"lease_owner":      "",
+			"lease_expires_at": nil,
+		}).Error
+}
+
+func (r *reminderRepository) updateLeased(id uint, owner string, updates map[string]interface{}) error {
+	result := r.db.Model(&models.Reminder{}).
+		Where("id = ? AND lease_owner = ?", id, owner).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrReminderLeaseLost
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"time"
+
+	"task-api/models"
+)
+
+type SessionRepository interface {
+	Create(session *models.Session) error
+	GetByID(id uint) (*models.Session, error)
+	ListActiveByUserID(userID uint) ([]models.Session, error)
+	Touch(familyID, ipAddress, userAgent string, expiresAt time.Time) error
+	RevokeByFamilyID(familyID string) error
+	RevokeAllForUser(userID uint) error
+	RevokeAllForUserExcept(userID uint, familyID string) error
+	ListRevokedFamilyIDsSince(since time.Time) ([]string, error)
+}
\ No newline at end of file
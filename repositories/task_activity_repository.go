@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"task-api/models"
+)
+
+type TaskActivityRepository interface {
+	Create(activity *models.TaskActivity) error
+	CreateBatch(activities []models.TaskActivity) error
+	ListByTaskID(taskID uint, pagination PaginationParams) ([]models.TaskActivity, PaginationResult, error)
+}
\ No newline at end of file
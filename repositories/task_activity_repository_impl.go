@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"encoding/json"
+	"time"
+
+	"task-api/models"
+
+	"gorm.io/gorm"
+)
+
+type taskActivityRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskActivityRepository(db *gorm.DB) TaskActivityRepository {
+	return &taskActivityRepository{
+		db: db,
+	}
+}
+
+func (r *taskActivityRepository) Create(activity *models.TaskActivity) error {
+	return r.db.Omit("Actor").Create(activity).Error
+}
+
+func (r *taskActivityRepository) CreateBatch(activities []models.TaskActivity) error {
+	if len(activities) == 0 {
+		return nil
+	}
+	return r.db.Omit("Actor").Create(&activities).Error
+}
+
+func (r *taskActivityRepository) ListByTaskID(taskID uint, pagination PaginationParams) ([]models.TaskActivity, PaginationResult, error) {
+	query := r.db.Model(&models.TaskActivity{}).Where("task_id = ?", taskID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, PaginationResult{}, err
+	}
+
+	var activities []models.TaskActivity
+	err := query.Preload("Actor").
+		Offset(pagination.GetOffset()).
+		Limit(pagination.PageSize).
+		Order("created_at DESC, id DESC").
+		Find(&activities).Error
+	if err != nil {
+		return nil, PaginationResult{}, err
+	}
+
+	return activities, NewPaginationResult(pagination.Page, pagination.PageSize, total), nil
+}
+
+// recordTaskActivities appends the same activity entry to every task matched
+// by tasks. Bulk writes call it in their transaction before changing the rows,
+// so that the entries land exactly on the tasks being changed.
+func recordTaskActivities(tx *gorm.DB, tasks *gorm.DB, actorID uint, action models.TaskActivityAction, changes []models.TaskFieldChange) error {
+	if changes == nil {
+		changes = []models.TaskFieldChange{}
+	}
+
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	return tx.Exec(
+		"INSERT INTO task_activities (created_at, task_id, actor_id, action, changes) ?",
+		tasks.Select("?, tasks.id, ?, ?, CAST(? AS jsonb)", time.Now(), actorID, action, string(encoded)),
+	).Error
+}
\ No newline at end of file
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"task-api/models"
+)
+
+type TaskDependencyRepository interface {
+	InWorkspace(workspaceID uint) TaskDependencyRepository
+	Create(dependency *models.TaskDependency) error
+	Get(blockerID, blockedID uint) (*models.TaskDependency, error)
+	Delete(id uint) error
+	ListByUserID(userID uint) ([]models.TaskDependency, error)
+	ListBlockers(taskID uint) ([]models.Task, error)
+	ListBlocking(taskID uint) ([]models.Task, error)
+	ListOpenBlockerIDs(taskIDs []uint) (map[uint][]uint, error)
+}
\ No newline at end of file
@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"task-api/models"
+
+	"gorm.io/gorm"
+)
+
+type taskDependencyRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskDependencyRepository(db *gorm.DB) TaskDependencyRepository {
+	return &taskDependencyRepository{
+		db: db,
+	}
+}
+
+func (r *taskDependencyRepository) InWorkspace(workspaceID uint) TaskDependencyRepository {
+	return &taskDependencyRepository{
+		db: ScopeToWorkspace(r.db, workspaceID),
+	}
+}
+
+func (r *taskDependencyRepository) Create(dependency *models.TaskDependency) error {
+	return r.db.Omit("Blocker", "Blocked").Create(dependency).Error
+}
+
+func (r *taskDependencyRepository) Get(blockerID, blockedID uint) (*models.TaskDependency, error) {
+	var dependency models.TaskDependency
+	err := r.db.Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).First(&dependency).Error
+	if err != nil {
+		return nil, err
+	}
+	return &dependency, nil
+}
+
+func (r *taskDependencyRepository) Delete(id uint) error {
+	return r.db.Delete(&models.TaskDependency{}, id).Error
+}
+
+func (r *taskDependencyRepository) ListByUserID(userID uint) ([]models.TaskDependency, error) {
+	var dependencies []models.TaskDependency
+	err := r.db.Joins("JOIN tasks blocked ON blocked.id = task_dependencies.blocked_id AND blocked.deleted_at IS NULL").
+		Joins("JOIN tasks blocker ON blocker.id = task_dependencies.blocker_id AND blocker.deleted_at IS NULL").
+		Where("blocked.user_id = ?", userID).
+		Find(&dependencies).Error
+	return dependencies, err
+}
+
+func (r *taskDependencyRepository) ListBlockers(taskID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.db.Joins("JOIN task_dependencies ON task_dependencies.blocker_id = tasks.id").
+		Where("task_dependencies.blocked_id = ?", taskID).
+		Order("tasks.id ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskDependencyRepository) ListBlocking(taskID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.db.Joins("JOIN task_dependencies ON task_dependencies.blocked_id = tasks.id").
+		Where("task_dependencies.blocker_id = ?", taskID).
+		Order("tasks.id ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskDependencyRepository) ListOpenBlockerIDs(taskIDs []uint) (map[uint][]uint, error) {
+	blockers := make(map[uint][]uint, len(taskIDs))
+	if len(taskIDs) == 0 {
+		return blockers, nil
+	}
+
+	var rows []struct {
+		BlockedID uint
+		BlockerID uint
+	}
+	err := r.db.Model(&models.TaskDependency{}).
+		Select("task_dependencies.blocked_id, task_dependencies.blocker_id").
+		Joins("JOIN tasks ON tasks.id = task_dependencies.blocker_id AND tasks.deleted_at IS NULL").
+		Where("task_dependencies.blocked_id IN ? AND tasks.status_category NOT IN ?", taskIDs, closedStatusCategories).
+		Order("task_dependencies.blocker_id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		blockers[row.BlockedID] = append(blockers[row.BlockedID], row.BlockerID)
+	}
+	return blockers, nil
+}
\ No newline at end of file
@@ -0,0 +1,253 @@
+package repositories
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"task-api/models"
+
+	"gorm.io/gorm"
+)
+
+type TaskSortField string
+
+const (
+	TaskSortDueDate   TaskSortField = "due_date"
+	TaskSortPriority  TaskSortField = "priority"
+	TaskSortCreatedAt TaskSortField = "created_at"
+	TaskSortUpdatedAt TaskSortField = "updated_at"
+	TaskSortTitle     TaskSortField = "title"
+)
+
+var taskSortKeys = map[TaskSortField]cursorKey{
+	TaskSortDueDate:   {Name: string(TaskSortDueDate), Expr: "tasks.due_date", Kind: cursorKindTime, Nullable: true},
+	TaskSortPriority:  {Name: string(TaskSortPriority), Expr: "CASE tasks.priority WHEN 'high' THEN 3 WHEN 'medium' THEN 2 WHEN 'low' THEN 1 ELSE 0 END", Kind: cursorKindInt},
+	TaskSortCreatedAt: {Name: string(TaskSortCreatedAt), Expr: "tasks.created_at", Kind: cursorKindTime},
+	TaskSortUpdatedAt: {Name: string(TaskSortUpdatedAt), Expr: "tasks.updated_at", Kind: cursorKindTime},
+	TaskSortTitle:     {Name: string(TaskSortTitle), Expr: "LOWER(tasks.title)", Kind: cursorKindString},
+}
+
+var closedStatusCategories = []models.TaskStatusCategory{models.TaskStatusCategoryDone, models.TaskStatusCategoryCancelled}
+
+var taskPriorityRanks = map[models.TaskPriority]int64{
+	models.TaskPriorityHigh:   3,
+	models.TaskPriorityMedium: 2,
+	models.TaskPriorityLow:    1,
+}
+
+func IsValidTaskSortField(field TaskSortField) bool {
+	_, ok := taskSortKeys[field]
+	return ok
+}
+
+const (
+	taskSearchQuery   = "websearch_to_tsquery('english', ?)"
+	taskSearchOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=\" ... \""
+)
+
+type LabelMatch string
+
+const (
+	LabelMatchAny LabelMatch = "any"
+	LabelMatchAll LabelMatch = "all"
+)
+
+type TaskSort struct {
+	Field      TaskSortField
+	Descending bool
+}
+
+type TaskFilter struct {
+	Search          string
+	AssigneeID      *uint
+	SharedWithID    *uint
+	Statuses        []models.TaskStatus
+	Priorities      []models.TaskPriority
+	ParentID        *uint
+	TopLevelOnly    bool
+	ProjectID       *uint
+	WithoutProject  bool
+	IncludeArchived bool
+	LabelIDs        []uint
+	LabelMatch      LabelMatch
+	DueAfter        *time.Time
+	DueBefore       *time.Time
+	Overdue         *bool
+	Blocked         *bool
+	CompletedAfter  *time.Time
+	CompletedBefore *time.Time
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
+	UpdatedAfter    *time.Time
+	UpdatedBefore   *time.Time
+	Sort            []TaskSort
+}
+
+func (f TaskFilter) Apply(query *gorm.DB) *gorm.DB {
+	if f.Search != "" {
+		query = query.Where("tasks.search_vector @@ "+taskSearchQuery, f.Search)
+	}
+	if f.AssigneeID != nil {
+		query = query.Where("tasks.assignee_id = ?", *f.AssigneeID)
+	}
+	if f.SharedWithID != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM task_shares WHERE task_shares.task_id = tasks.id AND task_shares.user_id = ?)", *f.SharedWithID)
+	}
+	if len(f.Statuses) > 0 {
+		query = query.Where("tasks.status IN ?", f.Statuses)
+	}
+	if len(f.Priorities) > 0 {
+		query = query.Where("tasks.priority IN ?", f.Priorities)
+	}
+	if f.ParentID != nil {
+		query = query.Where("tasks.parent_id = ?", *f.ParentID)
+	} else if f.TopLevelOnly {
+		query = query.Where("tasks.parent_id IS NULL")
+	}
+	switch {
+	case f.ProjectID != nil:
+		query = query.Where("tasks.project_id = ?", *f.ProjectID)
+	case f.WithoutProject:
+		query = query.Where("tasks.project_id IS NULL")
+	case !f.IncludeArchived:
+		query = query.Where("(tasks.project_id IS NULL OR NOT EXISTS (SELECT 1 FROM projects WHERE projects.id = tasks.project_id AND projects.archived))")
+	}
+	if len(f.LabelIDs) > 0 {
+		if f.LabelMatch == LabelMatchAll {
+			query = query.Where("(SELECT COUNT(DISTINCT task_labels.label_id) FROM task_labels WHERE task_labels.task_id = tasks.id AND task_labels.label_id IN ?) = ?", f.LabelIDs, len(uniqueIDs(f.LabelIDs)))
+		} else {
+			query = query.Where("EXISTS (SELECT 1 FROM task_labels WHERE task_labels.task_id = tasks.id AND task_labels.label_id IN ?)", f.LabelIDs)
+		}
+	}
+	if f.DueAfter != nil {
+		query = query.Where("tasks.due_date >= ?", *f.DueAfter)
+	}
+	if f.DueBefore != nil {
+		query = query.Where("tasks.due_date <= ?", *f.DueBefore)
+	}
+	if f.Overdue != nil {
+		if *f.Overdue {
+			query = query.Where("tasks.due_date < ? AND tasks.status_category NOT IN ?", time.Now(), closedStatusCategories)
+		} else {
+			query = query.Where("(tasks.due_date IS NULL OR tasks.due_date >= ? OR tasks.status_category IN ?)", time.Now(), closedStatusCategories)
+		}
+	}
+	if f.Blocked != nil {
+		blockedSQL := "EXISTS (SELECT 1 FROM task_dependencies JOIN tasks blockers ON blockers.id = task_dependencies.blocker_id AND blockers.deleted_at IS NULL WHERE task_dependencies.blocked_id = tasks.id AND blockers.status_category NOT IN ?)"
+		if *f.Blocked {
+			query = query.Where(blockedSQL, closedStatusCategories)
+		} else {
+			query = query.Where("NOT "+blockedSQL, closedStatusCategories)
+		}
+	}
+	if f.CompletedAfter != nil {
+		query = query.Where("tasks.completed_at >= ?", *f.CompletedAfter)
+	}
+	if f.CompletedBefore != nil {
+		query = query.Where("tasks.completed_at <= ?", *f.CompletedBefore)
+	}
+	if f.CreatedAfter != nil {
+		query = query.Where("tasks.created_at >= ?", *f.CreatedAfter)
+	}
+	if f.CreatedBefore != nil {
+		query = query.Where("tasks.created_at <= ?", *f.CreatedBefore)
+	}
+	if f.UpdatedAfter != nil {
+		query = query.Where("tasks.updated_at >= ?", *f.UpdatedAfter)
+	}
+	if f.UpdatedBefore != nil {
+		query = query.Where("tasks.updated_at <= ?", *f.UpdatedBefore)
+	}
+	return query
+}
+
+func (f TaskFilter) SelectColumns(query *gorm.DB) *gorm.DB {
+	if f.Search == "" {
+		return query
+	}
+
+	return query.Select(
+		"tasks.*, "+
+			"ts_rank(tasks.search_vector, "+taskSearchQuery+") AS search_rank, "+
+			"ts_headline('english', tasks.title, "+taskSearchQuery+", ?) AS search_title, "+
+			"ts_headline('english', coalesce(tasks.description, ''), "+taskSearchQuery+", ?) AS search_snippet",
+		f.Search,
+		f.Search, taskSearchOptions,
+		f.Search, taskSearchOptions,
+	)
+}
+
+func (f TaskFilter) OrderClause() string {
+	return keysetOrder(f.sortKeys(), false)
+}
+
+func (f TaskFilter) sortKeys() []cursorKey {
+	if len(f.Sort) == 0 && f.Search != "" {
+		return []cursorKey{
+			{Name: "rank", Expr: "ts_rank(tasks.search_vector, " + taskSearchQuery + ")", Vars: []interface{}{f.Search}, OrderExpr: "search_rank", Kind: cursorKindFloat, Descending: true},
+			{Name: "id", Expr: "tasks.id", Kind: cursorKindInt, Descending: true},
+		}
+	}
+	if len(f.Sort) == 0 {
+		return []cursorKey{
+			{Name: string(TaskSortCreatedAt), Expr: "tasks.created_at", Kind: cursorKindTime, Descending: true},
+			{Name: "id", Expr: "tasks.id", Kind: cursorKindInt, Descending: true},
+		}
+	}
+
+	keys := make([]cursorKey, 0, len(f.Sort)+1)
+	for _, sort := range f.Sort {
+		key, ok := taskSortKeys[sort.Field]
+		if !ok {
+			continue
+		}
+		key.Descending = sort.Descending
+		keys = append(keys, key)
+	}
+	return append(keys, cursorKey{Name: "id", Expr: "tasks.id", Kind: cursorKindInt})
+}
+
+func (f TaskFilter) cursorSignature(keys []cursorKey) string {
+	if f.Search == "" {
+		return cursorSignature(keys, "")
+	}
+	sum := sha256.Sum256([]byte(f.Search))
+	return cursorSignature(keys, hex.EncodeToString(sum[:8]))
+}
+
+func taskCursorValues(task *models.Task, keys []cursorKey) []interface{} {
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		switch key.Name {
+		case string(TaskSortDueDate):
+			values[i] = task.DueDate
+		case string(TaskSortPriority):
+			values[i] = taskPriorityRanks[task.Priority]
+		case string(TaskSortCreatedAt):
+			values[i] = task.CreatedAt
+		case string(TaskSortUpdatedAt):
+			values[i] = task.UpdatedAt
+		case string(TaskSortTitle):
+			values[i] = strings.ToLower(task.Title)
+		case "rank":
+			values[i] = task.SearchRank
+		case "id":
+			values[i] = int64(task.ID)
+		}
+	}
+	return values
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
\ No newline at end of file
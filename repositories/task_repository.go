@@ -4,11 +4,33 @@ import (
 	"task-api/models"
 )
 
+type TaskProgress struct {
+	Total     int64
+	Completed int64
+}
+
+// TaskTx holds repositories bound to one database transaction.
+type TaskTx struct {
+	Tasks      TaskRepository
+	Activities TaskActivityRepository
+	Reminders  ReminderRepository
+}
+
 type TaskRepository interface {
+	InWorkspace(workspaceID uint) TaskRepository
 	Create(task *models.Task) error
 	GetByID(id uint) (*models.Task, error)
-	GetByUserID(userID uint, pagination PaginationParams) ([]models.Task, PaginationResult, error)
+	GetByUserID(userID uint, filter TaskFilter, pagination PaginationParams) ([]models.Task, PaginationResult, error)
 	Update(task *models.Task) error
+	ReplaceLabels(task *models.Task, labels []models.Label) error
 	Delete(id uint) error
-	List(pagination PaginationParams) ([]models.Task, PaginationResult, error)
+	ListChildren(parentID uint) ([]models.Task, error)
+	ListDescendantIDs(taskID uint) ([]uint, error)
+	CountOpenDescendants(taskID uint) (int64, error)
+	ListOpenDescendants(taskID uint) ([]models.Task, error)
+	GetProgress(taskIDs []uint) (map[uint]TaskProgress, error)
+	ListRecurrenceSeries(seriesID uint) ([]models.Task, error)
+	EndRecurrenceSeries(seriesID uint) error
+	List(filter TaskFilter, pagination PaginationParams) ([]models.Task, PaginationResult, error)
+	Transaction(fn func(tx TaskTx) error) error
 }
\ No newline at end of file
@@ -1,11 +1,19 @@
 package repositories
 
 import (
+	"time"
+
 	"task-api/models"
 
 	"gorm.io/gorm"
 )
 
+const taskSubtreeCTE = `WITH RECURSIVE subtree AS (
+	SELECT id FROM tasks WHERE parent_id = ? AND deleted_at IS NULL AND ?
+	UNION
+	SELECT tasks.id FROM tasks JOIN subtree ON tasks.parent_id = subtree.id WHERE tasks.deleted_at IS NULL AND ?
+) `
+
 type taskRepository struct {
 	db *gorm.DB
 }
@@ -16,65 +24,197 @@ func NewTaskRepository(db *gorm.DB) TaskRepository {
 	}
 }
 
+func (r *taskRepository) InWorkspace(workspaceID uint) TaskRepository {
+	return &taskRepository{
+		db: ScopeToWorkspace(r.db, workspaceID),
+	}
+}
+
 func (r *taskRepository) Create(task *models.Task) error {
 	return r.db.Create(task).Error
 }
 
 func (r *taskRepository) GetByID(id uint) (*models.Task, error) {
 	var task models.Task
-	err := r.db.Preload("User").First(&task, id).Error
+	err := r.db.Preload("User").Preload("Labels").First(&task, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &task, nil
 }
 
-func (r *taskRepository) GetByUserID(userID uint, pagination PaginationParams) ([]models.Task, PaginationResult, error) {
-	var tasks []models.Task
-	var total int64
+func (r *taskRepository) GetByUserID(userID uint, filter TaskFilter, pagination PaginationParams) ([]models.Task, PaginationResult, error) {
+	query := filter.Apply(r.db.Model(&models.Task{}).Where("tasks.user_id = ?", userID))
+	return r.findPage(query, filter, pagination)
+}
 
-	query := r.db.Model(&models.Task{}).Where("user_id = ?", userID)
+func (r *taskRepository) Update(task *models.Task) error {
+	return r.db.Omit("Labels").Save(task).Error
+}
 
-	if err := query.Count(&total).Error; err != nil {
-		return nil, PaginationResult{}, err
+func (r *taskRepository) ReplaceLabels(task *models.Task, labels []models.Label) error {
+	return r.db.Model(task).Association("Labels").Replace(labels)
+}
+
+func (r *taskRepository) Delete(id uint) error {
+	inWorkspace, err := rawWorkspaceFilter(r.db, "tasks.workspace_id")
+	if err != nil {
+		return err
 	}
 
-	err := query.Preload("User").
-		Offset(pagination.GetOffset()).
-		Limit(pagination.PageSize).
-		Order("created_at DESC").
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(taskSubtreeCTE+"UPDATE tasks SET deleted_at = ? WHERE id IN (SELECT id FROM subtree)",
+			id, inWorkspace, inWorkspace, time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Task{}, id).Error
+	})
+}
+
+func (r *taskRepository) ListChildren(parentID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.db.Preload("User").
+		Preload("Labels").
+		Where("parent_id = ?", parentID).
+		Order("created_at ASC, id ASC").
 		Find(&tasks).Error
+	return tasks, err
+}
 
+func (r *taskRepository) ListDescendantIDs(taskID uint) ([]uint, error) {
+	inWorkspace, err := rawWorkspaceFilter(r.db, "tasks.workspace_id")
 	if err != nil {
-		return nil, PaginationResult{}, err
+		return nil, err
 	}
 
-	paginationResult := NewPaginationResult(pagination.Page, pagination.PageSize, total)
-	return tasks, paginationResult, nil
+	var ids []uint
+	err = r.db.Raw(taskSubtreeCTE+"SELECT id FROM subtree", taskID, inWorkspace, inWorkspace).Scan(&ids).Error
+	return ids, err
 }
 
-func (r *taskRepository) Update(task *models.Task) error {
-	return r.db.Save(task).Error
+func (r *taskRepository) CountOpenDescendants(taskID uint) (int64, error) {
+	inWorkspace, err := rawWorkspaceFilter(r.db, "tasks.workspace_id")
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err = r.db.Raw(taskSubtreeCTE+"SELECT COUNT(*) FROM tasks WHERE id IN (SELECT id FROM subtree) AND status_category NOT IN ?",
+		taskID, inWorkspace, inWorkspace, closedStatusCategories).
+		Scan(&count).Error
+	return count, err
 }
 
-func (r *taskRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Task{}, id).Error
+func (r *taskRepository) ListOpenDescendants(taskID uint) ([]models.Task, error) {
+	ids, err := r.ListDescendantIDs(taskID)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var tasks []models.Task
+	err = r.db.Where("id IN ? AND status_category NOT IN ?", ids, closedStatusCategories).
+		Order("id ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskRepository) GetProgress(taskIDs []uint) (map[uint]TaskProgress, error) {
+	progress := make(map[uint]TaskProgress, len(taskIDs))
+	if len(taskIDs) == 0 {
+		return progress, nil
+	}
+
+	inWorkspace, err := rawWorkspaceFilter(r.db, "tasks.workspace_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		RootID    uint
+		Total     int64
+		Completed int64
+	}
+	err = r.db.Raw(`WITH RECURSIVE tree AS (
+	SELECT parent_id AS root_id, id FROM tasks WHERE parent_id IN ? AND deleted_at IS NULL AND ?
+	UNION
+	SELECT tree.root_id, tasks.id FROM tasks JOIN tree ON tasks.parent_id = tree.id WHERE tasks.deleted_at IS NULL AND ?
+)
+SELECT tree.root_id,
+	COUNT(*) FILTER (WHERE tasks.status_category <> ?) AS total,
+	COUNT(*) FILTER (WHERE tasks.status_category = ?) AS completed
+FROM tree JOIN tasks ON tasks.id = tree.id
+GROUP BY tree.root_id`, taskIDs, inWorkspace, inWorkspace, models.TaskStatusCategoryCancelled, models.TaskStatusCategoryDone).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		progress[row.RootID] = TaskProgress{Total: row.Total, Completed: row.Completed}
+	}
+	return progress, nil
 }
 
-func (r *taskRepository) List(pagination PaginationParams) ([]models.Task, PaginationResult, error) {
+func (r *taskRepository) ListRecurrenceSeries(seriesID uint) ([]models.Task, error) {
 	var tasks []models.Task
-	var total int64
+	err := r.db.Preload("Labels").
+		Where("recurrence_series_id = ?", seriesID).
+		Order("recurrence_index ASC, id ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
 
-	query := r.db.Model(&models.Task{})
+func (r *taskRepository) EndRecurrenceSeries(seriesID uint) error {
+	return r.db.Model(&models.Task{}).
+		Where("recurrence_series_id = ?", seriesID).
+		Updates(map[string]interface{}{
+			"recurrence_rule":     "",
+			"recurrence_timezone": "",
+		}).Error
+}
 
-	if err := query.Count(&total).Error; err != nil {
-		return nil, PaginationResult{}, err
+func (r *taskRepository) List(filter TaskFilter, pagination PaginationParams) ([]models.Task, PaginationResult, error) {
+	query := filter.Apply(r.db.Model(&models.Task{}))
+	return r.findPage(query, filter, pagination)
+}
+
+// Transaction runs fn with repositories bound to one database transaction, so
+// that a task write, its activity entries and its reminders commit or roll
+// back together.
+func (r *taskRepository) Transaction(fn func(tx TaskTx) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(TaskTx{
+			Tasks:      &taskRepository{db: tx},
+			Activities: &taskActivityRepository{db: tx},
+			Reminders:  &reminderRepository{db: tx},
+		})
+	})
+}
+
+func (r *taskRepository) findPage(query *gorm.DB, filter TaskFilter, pagination PaginationParams) ([]models.Task, PaginationResult, error) {
+	var total int64
+	if pagination.IncludeTotal || !pagination.IsCursor() {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, PaginationResult{}, err
+		}
 	}
 
-	err := query.Preload("User").
+	if pagination.IsCursor() {
+		tasks, paginationResult, err := r.findCursorPage(query, filter, pagination)
+		if err != nil {
+			return nil, PaginationResult{}, err
+		}
+		if pagination.IncludeTotal {
+			paginationResult.Total = &total
+		}
+		return tasks, paginationResult, nil
+	}
+
+	var tasks []models.Task
+	err := filter.SelectColumns(query).Preload("User").
+		Preload("Labels").
 		Offset(pagination.GetOffset()).
 		Limit(pagination.PageSize).
-		Order("created_at DESC").
+		Order(filter.OrderClause()).
 		Find(&tasks).Error
 
 	if err != nil {
@@ -82,5 +222,74 @@ func (r *taskRepository) List(pagination PaginationParams) ([]models.Task, Pagin
 	}
 
 	paginationResult := NewPaginationResult(pagination.Page, pagination.PageSize, total)
+	return tasks, paginationResult, nil
+}
+
+func (r *taskRepository) findCursorPage(query *gorm.DB, filter TaskFilter, pagination PaginationParams) ([]models.Task, PaginationResult, error) {
+	keys := filter.sortKeys()
+	signature := filter.cursorSignature(keys)
+
+	backward := pagination.Before != ""
+	cursor := pagination.After
+	if backward {
+		cursor = pagination.Before
+	}
+
+	query = filter.SelectColumns(query)
+	if cursor != "" {
+		values, err := decodeCursor(cursor, signature, keys)
+		if err != nil {
+			return nil, PaginationResult{}, err
+		}
+		condition, vars := keysetCondition(keys, values, backward)
+		query = query.Where(condition, vars...)
+	}
+
+	var tasks []models.Task
+	err := query.Preload("User").
+		Preload("Labels").
+		Limit(pagination.PageSize + 1).
+		Order(keysetOrder(keys, backward)).
+		Find(&tasks).Error
+
+	if err != nil {
+		return nil, PaginationResult{}, err
+	}
+
+	hasMore := len(tasks) > pagination.PageSize
+	if hasMore {
+		tasks = tasks[:pagination.PageSize]
+	}
+	if backward {
+		for i, j := 0, len(tasks)-1; i < j; i, j = i+1, j-1 {
+			tasks[i], tasks[j] = tasks[j], tasks[i]
+		}
+	}
+
+	paginationResult := PaginationResult{PageSize: pagination.PageSize}
+	if len(tasks) == 0 {
+		return tasks, paginationResult, nil
+	}
+
+	hasNext, hasPrev := hasMore, cursor != ""
+	if backward {
+		hasNext, hasPrev = true, hasMore
+	}
+
+	if hasNext {
+		next, err := encodeCursor(signature, taskCursorValues(&tasks[len(tasks)-1], keys))
+		if err != nil {
+			return nil, PaginationResult{}, err
+		}
+		paginationResult.NextCursor = next
+	}
+	if hasPrev {
+		prev, err := encodeCursor(signature, taskCursorValues(&tasks[0], keys))
+		if err != nil {
+			return nil, PaginationResult{}, err
+		}
+		paginationResult.PrevCursor = prev
+	}
+
 	return tasks, paginationResult, nil
 }
\ No newline at end of file
@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type sqlRecorder struct {
+	statements []string
+}
+
+func (r *sqlRecorder) LogMode(logger.LogLevel) logger.Interface      { return r }
+func (r *sqlRecorder) Info(context.Context, string, ...interface{})  {}
+func (r *sqlRecorder) Warn(context.Context, string, ...interface{})  {}
+func (r *sqlRecorder) Error(context.Context, string, ...interface{}) {}
+func (r *sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.statements = append(r.statements, sql)
+}
+
+func dryRunDB(t *testing.T) (*gorm.DB, *sqlRecorder) {
+	t.Helper()
+	recorder := &sqlRecorder{}
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost", PreferSimpleProtocol: true}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 recorder,
+	})
+	if err != nil {
+		t.Fatalf("open dry-run db: %v", err)
+	}
+	return db, recorder
+}
+
+var workspaceConditionPattern = regexp.MustCompile(`tasks\.workspace_id = (\d+)`)
+
+func TestTaskSubtreeQueriesStayInWorkspace(t *testing.T) {
+	queries := []struct {
+		name string
+		run  func(repo TaskRepository) error
+	}{
+		{
+			name: "ListDescendantIDs",
+			run: func(repo TaskRepository) error {
+				_, err := repo.ListDescendantIDs(42)
+				return err
+			},
+		},
+		{
+			name: "CountOpenDescendants",
+			run: func(repo TaskRepository) error {
+				_, err := repo.CountOpenDescendants(42)
+				return err
+			},
+		},
+		{
+			name: "GetProgress",
+			run: func(repo TaskRepository) error {
+				_, err := repo.GetProgress([]uint{42, 43})
+				return err
+			},
+		},
+	}
+
+	for _, query := range queries {
+		t.Run(query.name+" scoped", func(t *testing.T) {
+			db, recorder := dryRunDB(t)
+			if err := query.run(NewTaskRepository(db).InWorkspace(7)); err != nil && !errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(recorder.statements) != 1 {
+				t.Fatalf("expected 1 statement, got %d: %q", len(recorder.statements), recorder.statements)
+			}
+
+			sql := recorder.statements[0]
+			conditions := workspaceConditionPattern.FindAllStringSubmatch(sql, -1)
+			if len(conditions) != 2 {
+				t.Fatalf("expected a workspace condition in both members of the recursion, got %d in %q", len(conditions), sql)
+			}
+			for _, condition := range conditions {
+				if condition[1] != "7" {
+					t.Errorf("expected workspace 7, got %s in %q", condition[1], sql)
+				}
+			}
+			if strings.Contains(sql, "TRUE") {
+				t.Errorf("scoped query must not be opened up: %q", sql)
+			}
+		})
+
+		t.Run(query.name+" across workspaces", func(t *testing.T) {
+			db, recorder := dryRunDB(t)
+			if err := query.run(NewTaskRepository(AcrossWorkspaces(db))); err != nil && !errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(recorder.statements) != 1 {
+				t.Fatalf("expected 1 statement, got %d: %q", len(recorder.statements), recorder.statements)
+			}
+			if sql := recorder.statements[0]; strings.Contains(sql, "workspace_id") {
+				t.Errorf("unexpected workspace condition in %q", sql)
+			}
+		})
+
+		t.Run(query.name+" unscoped", func(t *testing.T) {
+			db, recorder := dryRunDB(t)
+			if err := query.run(NewTaskRepository(db)); !errors.Is(err, ErrWorkspaceScopeMissing) {
+				t.Fatalf("expected ErrWorkspaceScopeMissing, got %v", err)
+			}
+			if len(recorder.statements) != 0 {
+				t.Errorf("expected no statement, got %q", recorder.statements)
+			}
+		})
+	}
+}
+
+func TestTaskDeleteRequiresWorkspaceScope(t *testing.T) {
+	db, recorder := dryRunDB(t)
+	if err := NewTaskRepository(db).Delete(42); !errors.Is(err, ErrWorkspaceScopeMissing) {
+		t.Fatalf("expected ErrWorkspaceScopeMissing, got %v", err)
+	}
+	if len(recorder.statements) != 0 {
+		t.Errorf("expected no statement, got %q", recorder.statements)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"task-api/models"
+)
+
+type TaskShareRepository interface {
+	Upsert(share *models.TaskShare) error
+	Get(taskID, userID uint) (*models.TaskShare, error)
+	ListByTaskID(taskID uint) ([]models.TaskShare, error)
+	Delete(taskID, userID uint) error
+}
\ No newline at end of file
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"task-api/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type taskShareRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskShareRepository(db *gorm.DB) TaskShareRepository {
+	return &taskShareRepository{
+		db: db,
+	}
+}
+
+func (r *taskShareRepository) Upsert(share *models.TaskShare) error {
+	return r.db.Omit("Task", "User").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "task_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"permission", "updated_at"}),
+	}).Create(share).Error
+}
+
+func (r *taskShareRepository) Get(taskID, userID uint) (*models.TaskShare, error) {
+	var share models.TaskShare
+	err := r.db.Where("task_id = ? AND user_id = ?", taskID, userID).First(&share).Error
+	if err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+func (r *taskShareRepository) ListByTaskID(taskID uint) ([]models.TaskShare, error) {
+	var shares []models.TaskShare
+	err := r.db.Preload("User").
+		Where("task_id = ?", taskID).
+		Order("created_at ASC, id ASC").
+		Find(&shares).Error
+	return shares, err
+}
+
+func (r *taskShareRepository) Delete(taskID, userID uint) error {
+	return r.db.Where("task_id = ? AND user_id = ?", taskID, userID).Delete(&models.TaskShare{}).Error
+}
\ No newline at end of file
@@ -11,4 +11,5 @@ type UserRepository interface {
 	Update(user *models.User) error
 	Delete(id uint) error
 	EmailExists(email string) (bool, error)
+	List(pagination PaginationParams) ([]models.User, PaginationResult, error)
 }
\ No newline at end of file
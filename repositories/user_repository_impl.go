@@ -50,4 +50,28 @@ func (r *userRepository) EmailExists(email string) (bool, error) {
 	var count int64
 	err := r.db.Model(&models.User{}).Where("email = ?", email).Count(&count).Error
 	return count > 0, err
+}
+
+func (r *userRepository) List(pagination PaginationParams) ([]models.User, PaginationResult, error) {
+	var users []models.User
+	var total int64
+
+	query := r.db.Model(&models.User{})
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, PaginationResult{}, err
+	}
+
+	err := query.
+		Offset(pagination.GetOffset()).
+		Limit(pagination.PageSize).
+		Order("created_at DESC").
+		Find(&users).Error
+
+	if err != nil {
+		return nil, PaginationResult{}, err
+	}
+
+	paginationResult := NewPaginationResult(pagination.Page, pagination.PageSize, total)
+	return users, paginationResult, nil
 }
\ No newline at end of file
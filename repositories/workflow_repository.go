@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"task-api/models"
+)
+
+type TaskStatusUsage struct {
+	Status         models.TaskStatus
+	StatusCategory models.TaskStatusCategory
+}
+
+type WorkflowRepository interface {
+	InWorkspace(workspaceID uint) WorkflowRepository
+	ListByWorkspace(workspaceID uint) ([]models.WorkflowStatus, error)
+	ListByProject(projectID uint) ([]models.WorkflowStatus, error)
+	Replace(workspaceID uint, projectID *uint, statuses []models.WorkflowStatus, remap map[models.TaskStatus]models.TaskStatus, actorID uint) error
+	ListStatusUsage(workspaceID uint, projectID *uint) ([]TaskStatusUsage, error)
+}
\ No newline at end of file
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"task-api/models"
+
+	"gorm.io/gorm"
+)
+
+type workflowRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkflowRepository(db *gorm.DB) WorkflowRepository {
+	return &workflowRepository{
+		db: db,
+	}
+}
+
+func (r *workflowRepository) InWorkspace(workspaceID uint) WorkflowRepository {
+	return &workflowRepository{
+		db: ScopeToWorkspace(r.db, workspaceID),
+	}
+}
+
+func (r *workflowRepository) ListByWorkspace(workspaceID uint) ([]models.WorkflowStatus, error) {
+	var statuses []models.WorkflowStatus
+	err := r.db.Where("workspace_id = ? AND project_id IS NULL", workspaceID).
+		Order("position ASC, id ASC").
+		Find(&statuses).Error
+	return statuses, err
+}
+
+func (r *workflowRepository) ListByProject(projectID uint) ([]models.WorkflowStatus, error) {
+	var statuses []models.WorkflowStatus
+	err := r.db.Where("project_id = ?", projectID).
+		Order("position ASC, id ASC").
+		Find(&statuses).Error
+	return statuses, err
+}
+
+func (r *workflowRepository) Replace(workspaceID uint, projectID *uint, statuses []models.WorkflowStatus, remap map[models.TaskStatus]models.TaskStatus, actorID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for from, to := range remap {
+			changes := []models.TaskFieldChange{{Field: "status", Before: string(from), After: string(to)}}
+			if err := recordTaskActivities(tx, governedTasks(tx, workspaceID, projectID).Where("tasks.status = ?", from), actorID, models.TaskActivityUpdated, changes); err != nil {
+				return err
+			}
+			if err := governedTasks(tx, workspaceID, projectID).Where("tasks.status = ?", from).Update("status", to).Error; err != nil {
+				return err
+			}
+		}
+
+		query := tx.Where("workspace_id = ?", workspaceID)
+		if projectID != nil {
+			query = query.Where("project_id = ?", *projectID)
+		} else {
+			query = query.Where("project_id IS NULL")
+		}
+		if err := query.Delete(&models.WorkflowStatus{}).Error; err != nil {
+			return err
+		}
+
+		if len(statuses) == 0 {
+			return nil
+		}
+		return tx.Omit("Workspace").Create(&statuses).Error
+	})
+}
+
+func (r *workflowRepository) ListStatusUsage(workspaceID uint, projectID *uint) ([]TaskStatusUsage, error) {
+	var usage []TaskStatusUsage
+	err := governedTasks(r.db, workspaceID, projectID).
+		Distinct("tasks.status", "tasks.status_category").
+		Scan(&usage).Error
+	return usage, err
+}
+
+// governedTasks selects the tasks whose statuses come from the workflow of a
+// project, or of the workspace when projectID is nil.
+func governedTasks(db *gorm.DB, workspaceID uint, projectID *uint) *gorm.DB {
+	query := db.Model(&models.Task{}).Where("tasks.workspace_id = ?", workspaceID)
+	if projectID != nil {
+		return query.Where("tasks.project_id = ?", *projectID)
+	}
+	return query.Where("(tasks.project_id IS NULL OR NOT EXISTS (SELECT 1 FROM workflow_statuses WHERE workflow_statuses.project_id = tasks.project_id))")
+}
\ No newline at end of file
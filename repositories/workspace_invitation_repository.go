@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"time"
+
+	"task-api/models"
+)
+
+type WorkspaceInvitationRepository interface {
+	Create(invitation *models.WorkspaceInvitation) error
+	GetByID(id uint) (*models.WorkspaceInvitation, error)
+	GetByTokenHash(tokenHash string) (*models.WorkspaceInvitation, error)
+	ListPending(workspaceID uint, now time.Time) ([]models.WorkspaceInvitation, error)
+	Accept(invitation *models.WorkspaceInvitation, member *models.WorkspaceMember, acceptedAt time.Time) error
+	Delete(id uint) error
+}
\ No newline at end of file
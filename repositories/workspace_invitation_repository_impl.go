@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"time"
+
+	"task-api/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type workspaceInvitationRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkspaceInvitationRepository(db *gorm.DB) WorkspaceInvitationRepository {
+	return &workspaceInvitationRepository{
+		db: db,
+	}
+}
+
+func (r *workspaceInvitationRepository) Create(invitation *models.WorkspaceInvitation) error {
+	return r.db.Omit("Workspace").Create(invitation).Error
+}
+
+func (r *workspaceInvitationRepository) GetByID(id uint) (*models.WorkspaceInvitation, error) {
+	var invitation models.WorkspaceInvitation
+	err := r.db.First(&invitation, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *workspaceInvitationRepository) GetByTokenHash(tokenHash string) (*models.WorkspaceInvitation, error) {
+	var invitation models.WorkspaceInvitation
+	err := r.db.InnerJoins("Workspace").
+		Where("workspace_invitations.token_hash = ?", tokenHash).
+		First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *workspaceInvitationRepository) ListPending(workspaceID uint, now time.Time) ([]models.WorkspaceInvitation, error) {
+	var invitations []models.WorkspaceInvitation
+	err := r.db.Where("workspace_id = ? AND accepted_at IS NULL AND expires_at > ?", workspaceID, now).
+		Order("created_at DESC, id DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+func (r *workspaceInvitationRepository) Accept(invitation *models.WorkspaceInvitation, member *models.WorkspaceMember, acceptedAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.WorkspaceInvitation{}).
+			Where("id = ? AND accepted_at IS NULL", invitation.ID).
+			Update("accepted_at", acceptedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Omit("Workspace", "User").Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "workspace_id"}, {Name: "user_id"}},
+			DoNothing: true,
+		}).Create(member).Error
+	})
+}
+
+func (r *workspaceInvitationRepository) Delete(id uint) error {
+	return r.db.Delete(&models.WorkspaceInvitation{}, id).Error
+}
\ No newline at end of file
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"task-api/models"
+)
+
+type WorkspaceRepository interface {
+	Create(workspace *models.Workspace) error
+	GetByID(id uint) (*models.Workspace, error)
+	GetPersonal(userID uint) (*models.Workspace, error)
+	Update(workspace *models.Workspace) error
+	Delete(id, actorID uint) error
+	ListMemberships(userID uint) ([]models.WorkspaceMember, error)
+	GetMember(workspaceID, userID uint) (*models.WorkspaceMember, error)
+	ListMembers(workspaceID uint) ([]models.WorkspaceMember, error)
+	CountMembersByRole(workspaceID uint, role models.WorkspaceRole) (int64, error)
+	UpdateMember(member *models.WorkspaceMember) error
+	RemoveMember(workspaceID, userID, actorID uint) error
+}
\ No newline at end of file
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"time"
+
+	"task-api/models"
+
+	"gorm.io/gorm"
+)
+
+type workspaceRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkspaceRepository(db *gorm.DB) WorkspaceRepository {
+	return &workspaceRepository{
+		db: db,
+	}
+}
+
+func (r *workspaceRepository) Create(workspace *models.Workspace) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(workspace).Error; err != nil {
+			return err
+		}
+
+		return tx.Omit("Workspace", "User").Create(&models.WorkspaceMember{
+			WorkspaceID: workspace.ID,
+			UserID:      workspace.OwnerID,
+			Role:        models.WorkspaceRoleOwner,
+		}).Error
+	})
+}
+
+func (r *workspaceRepository) GetByID(id uint) (*models.Workspace, error) {
+	var workspace models.Workspace
+	err := r.db.First(&workspace, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+func (r *workspaceRepository) GetPersonal(userID uint) (*models.Workspace, error) {
+	var workspace models.Workspace
+	err := r.db.Where("owner_id = ? AND personal", userID).First(&workspace).Error
+	if err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+func (r *workspaceRepository) Update(workspace *models.Workspace) error {
+	return r.db.Save(workspace).Error
+}
+
+func (r *workspaceRepository) Delete(id, actorID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := recordTaskActivities(tx, ScopeToWorkspace(tx, id).Model(&models.Task{}), actorID, models.TaskActivityDeleted, nil); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Exec("UPDATE tasks SET deleted_at = ? WHERE workspace_id = ? AND deleted_at IS NULL", now, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE projects SET deleted_at = ? WHERE workspace_id = ? AND deleted_at IS NULL", now, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM workflow_statuses WHERE workspace_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&models.WorkspaceInvitation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&models.WorkspaceMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Workspace{}, id).Error
+	})
+}
+
+func (r *workspaceRepository) ListMemberships(userID uint) ([]models.WorkspaceMember, error) {
+	var members []models.WorkspaceMember
+	err := r.db.InnerJoins("Workspace").
+		Where("workspace_members.user_id = ?", userID).
+		Order(`"Workspace".personal DESC, "Workspace".name ASC, workspace_members.id ASC`).
+		Find(&members).Error
+	return members, err
+}
+
+func (r *workspaceRepository) GetMember(workspaceID, userID uint) (*models.WorkspaceMember, error) {
+	var member models.WorkspaceMember
+	err := r.db.InnerJoins("Workspace").
+		Where("workspace_members.workspace_id = ? AND workspace_members.user_id = ?", workspaceID, userID).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *workspaceRepository) ListMembers(workspaceID uint) ([]models.WorkspaceMember, error) {
+	var members []models.WorkspaceMember
+	err := r.db.Preload("User").
+		Where("workspace_id = ?", workspaceID).
+		Order("created_at ASC, id ASC").
+		Find(&members).Error
+	return members, err
+}
+
+func (r *workspaceRepository) CountMembersByRole(workspaceID uint, role models.WorkspaceRole) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.WorkspaceMember{}).
+		Where("workspace_id = ? AND role = ?", workspaceID, role).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *workspaceRepository) UpdateMember(member *models.WorkspaceMember) error {
+	return r.db.Omit("Workspace", "User").Save(member).Error
+}
+
+func (r *workspaceRepository) RemoveMember(workspaceID, userID, actorID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Delete(&models.WorkspaceMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM task_shares WHERE user_id = ? AND task_id IN (SELECT id FROM tasks WHERE workspace_id = ?)", userID, workspaceID).Error; err != nil {
+			return err
+		}
+		changes := []models.TaskFieldChange{{Field: "assignee_id", Before: userID, After: nil}}
+		if err := recordTaskActivities(tx, ScopeToWorkspace(tx, workspaceID).Model(&models.Task{}).Where("tasks.assignee_id = ?", userID), actorID, models.TaskActivityUpdated, changes); err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE tasks SET assignee_id = NULL WHERE workspace_id = ? AND assignee_id = ?", workspaceID, userID).Error
+	})
+}
\ No newline at end of file
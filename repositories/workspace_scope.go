@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"task-api/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrWorkspaceScopeMissing  = errors.New("query on a workspace-scoped model without a workspace scope")
+	ErrWorkspaceScopeMismatch = errors.New("record belongs to a different workspace")
+)
+
+var workspaceScopedType = reflect.TypeOf((*models.WorkspaceScoped)(nil)).Elem()
+
+type workspaceScopeKey struct{}
+
+type workspaceScope struct {
+	workspaceID uint
+	all         bool
+}
+
+// ScopeToWorkspace returns a handle whose queries on workspace-scoped models
+// only see rows of the given workspace, and whose inserts are placed in it.
+func ScopeToWorkspace(db *gorm.DB, workspaceID uint) *gorm.DB {
+	return db.WithContext(context.WithValue(db.Statement.Context, workspaceScopeKey{}, workspaceScope{workspaceID: workspaceID}))
+}
+
+// AcrossWorkspaces opts a handle out of workspace scoping. It is meant for
+// background jobs and admin tools, never for user requests.
+func AcrossWorkspaces(db *gorm.DB) *gorm.DB {
+	return db.WithContext(context.WithValue(db.Statement.Context, workspaceScopeKey{}, workspaceScope{all: true}))
+}
+
+// rawWorkspaceFilter returns the condition raw SQL must add on column to stay
+// inside the workspace scope of db, since WorkspaceScopePlugin cannot see it.
+func rawWorkspaceFilter(db *gorm.DB, column string) (clause.Expr, error) {
+	scope, ok := db.Statement.Context.Value(workspaceScopeKey{}).(workspaceScope)
+	if !ok {
+		return clause.Expr{}, ErrWorkspaceScopeMissing
+	}
+	if scope.all {
+		return clause.Expr{SQL: "TRUE"}, nil
+	}
+	return clause.Expr{SQL: column + " = ?", Vars: []interface{}{scope.workspaceID}}, nil
+}
+
+// WorkspaceScopePlugin rejects statements on workspace-scoped models that were
+// issued through a handle with neither ScopeToWorkspace nor AcrossWorkspaces.
+// Raw SQL is left alone and must filter with rawWorkspaceFilter itself.
+type WorkspaceScopePlugin struct{}
+
+func (WorkspaceScopePlugin) Name() string {
+	return "workspace_scope"
+}
+
+func (p WorkspaceScopePlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("workspace_scope:create", p.beforeCreate); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("workspace_scope:query", p.constrain); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("workspace_scope:row", p.constrain); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("workspace_scope:update", p.constrain); err != nil {
+		return err
+	}
+	return callbacks.Delete().Before("gorm:delete").Register("workspace_scope:delete", p.constrain)
+}
+
+func (WorkspaceScopePlugin) scope(db *gorm.DB) (workspaceScope, bool) {
+	if db.Error != nil || db.Statement.Schema == nil || db.Statement.SQL.Len() > 0 {
+		return workspaceScope{}, false
+	}
+	if !reflect.PointerTo(db.Statement.Schema.ModelType).Implements(workspaceScopedType) {
+		return workspaceScope{}, false
+	}
+
+	scope, ok := db.Statement.Context.Value(workspaceScopeKey{}).(workspaceScope)
+	if !ok {
+		db.AddError(ErrWorkspaceScopeMissing)
+		return workspaceScope{}, false
+	}
+	return scope, !scope.all
+}
+
+func (p WorkspaceScopePlugin) constrain(db *gorm.DB) {
+	scope, ok := p.scope(db)
+	if !ok {
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "workspace_id"}, Value: scope.workspaceID},
+	}})
+}
+
+func (p WorkspaceScopePlugin) beforeCreate(db *gorm.DB) {
+	scope, ok := p.scope(db)
+	if !ok {
+		return
+	}
+
+	field := db.Statement.Schema.LookUpField("WorkspaceID")
+	if field == nil {
+		return
+	}
+
+	assign := func(value reflect.Value) {
+		current, isZero := field.ValueOf(db.Statement.Context, value)
+		if isZero {
+			db.AddError(field.Set(db.Statement.Context, value, scope.workspaceID))
+		} else if current != scope.workspaceID {
+			db.AddError(ErrWorkspaceScopeMismatch)
+		}
+	}
+
+	switch value := db.Statement.ReflectValue; value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			assign(reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		assign(value)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"io"
+	"task-api/models"
+	"time"
+)
+
+type AttachmentUpload struct {
+	Filename string
+	Size     int64
+	Content  io.Reader
+}
+
+type AttachmentResponseDTO struct {
+	ID          uint      `json:"id"`
+	TaskID      uint      `json:"task_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256"`
+	UploadedBy  uint      `json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func AttachmentToResponseDTO(attachment *models.Attachment) AttachmentResponseDTO {
+	return AttachmentResponseDTO{
+		ID:          attachment.ID,
+		TaskID:      attachment.TaskID,
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		SHA256:      attachment.SHA256,
+		UploadedBy:  attachment.UserID,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+func AttachmentsToResponseDTO(attachments []models.Attachment) []AttachmentResponseDTO {
+	result := make([]AttachmentResponseDTO, len(attachments))
+	for i, attachment := range attachments {
+		result[i] = AttachmentToResponseDTO(&attachment)
+	}
+	return result
+}
\ No newline at end of file
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"io"
+)
+
+type AttachmentService interface {
+	InWorkspace(workspaceID uint) AttachmentService
+	ListAttachments(userID, taskID uint) ([]AttachmentResponseDTO, error)
+	UploadAttachment(userID, taskID uint, upload AttachmentUpload) (*AttachmentResponseDTO, error)
+	OpenAttachment(userID, taskID, attachmentID uint) (*AttachmentResponseDTO, io.ReadCloser, error)
+	DeleteAttachment(userID, taskID, attachmentID uint) error
+}
\ No newline at end of file
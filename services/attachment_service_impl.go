@@ -0,0 +1,281 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"task-api/models"
+	"task-api/repositories"
+	"task-api/storage"
+	"task-api/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAttachmentNotFound      = errors.New("attachment not found")
+	ErrAttachmentTooLarge      = errors.New("attachment exceeds the maximum file size")
+	ErrAttachmentQuotaExceeded = errors.New("attachment storage quota exceeded")
+	ErrUnsupportedContentType  = errors.New("attachment content type is not allowed")
+)
+
+const (
+	contentSniffLength        = 512
+	maxAttachmentFilenameSize = 255
+)
+
+var allowedAttachmentTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+	"application/zip": true,
+	"text/plain":      true,
+	"text/csv":        true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         true,
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": true,
+}
+
+var officeDocumentTypes = map[string]string{
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+}
+
+type attachmentService struct {
+	attachmentRepo repositories.AttachmentRepository
+	permissions    TaskPermissions
+	blobStore      storage.BlobStore
+	policy         AttachmentPolicy
+}
+
+func NewAttachmentService(attachmentRepo repositories.AttachmentRepository, permissions TaskPermissions, blobStore storage.BlobStore, policy AttachmentPolicy) AttachmentService {
+	return &attachmentService{
+		attachmentRepo: attachmentRepo,
+		permissions:    permissions,
+		blobStore:      blobStore,
+		policy:         policy,
+	}
+}
+
+func (s *attachmentService) InWorkspace(workspaceID uint) AttachmentService {
+	scoped := *s
+	scoped.permissions = s.permissions.InWorkspace(workspaceID)
+	return &scoped
+}
+
+func (s *attachmentService) ListAttachments(userID, taskID uint) ([]AttachmentResponseDTO, error) {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.ListByTaskID(task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return AttachmentsToResponseDTO(attachments), nil
+}
+
+func (s *attachmentService) UploadAttachment(userID, taskID uint, upload AttachmentUpload) (*AttachmentResponseDTO, error) {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if upload.Size <= 0 {
+		return nil, NewValidationError("file", "file is empty")
+	}
+	if upload.Size > s.policy.MaxFileSize {
+		return nil, ErrAttachmentTooLarge
+	}
+
+	head := make([]byte, contentSniffLength)
+	n, err := io.ReadFull(upload.Content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	head = head[:n]
+
+	filename := sanitizeAttachmentFilename(upload.Filename)
+	contentType := sniffAttachmentContentType(head, filename)
+	if !allowedAttachmentTypes[contentType] {
+		return nil, ErrUnsupportedContentType
+	}
+
+	token, err := utils.GenerateSecureToken(18)
+	if err != nil {
+		return nil, err
+	}
+	key := "tasks/" + strconv.FormatUint(uint64(task.ID), 10) + "/" + token
+
+	attachment := &models.Attachment{
+		TaskID:      task.ID,
+		UserID:      userID,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        upload.Size,
+		StorageKey:  key,
+	}
+
+	if err := s.attachmentRepo.Reserve(attachment, s.policy.UserQuota); err != nil {
+		if errors.Is(err, repositories.ErrAttachmentQuotaExceeded) {
+			return nil, ErrAttachmentQuotaExceeded
+		}
+		return nil, err
+	}
+
+	hash := sha256.New()
+	var written byteCounter
+	content := io.TeeReader(io.MultiReader(bytes.NewReader(head), upload.Content), io.MultiWriter(hash, &written))
+
+	ctx := context.Background()
+	if err := s.blobStore.Put(ctx, key, content, upload.Size, contentType); err != nil {
+		s.releaseReservation(attachment)
+		return nil, err
+	}
+
+	if int64(written) != upload.Size {
+		s.deleteBlob(ctx, key)
+		s.releaseReservation(attachment)
+		return nil, NewValidationError("file", "file size does not match the upload")
+	}
+
+	attachment.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	if err := s.attachmentRepo.Complete(attachment.ID, attachment.SHA256); err != nil {
+		s.deleteBlob(ctx, key)
+		s.releaseReservation(attachment)
+		return nil, err
+	}
+	attachment.Pending = false
+
+	response := AttachmentToResponseDTO(attachment)
+	return &response, nil
+}
+
+func (s *attachmentService) OpenAttachment(userID, taskID, attachmentID uint) (*AttachmentResponseDTO, io.ReadCloser, error) {
+	attachment, err := s.getTaskAttachment(userID, taskID, attachmentID, TaskActionView)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobStore.Get(context.Background(), attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			return nil, nil, ErrAttachmentNotFound
+		}
+		return nil, nil, err
+	}
+
+	response := AttachmentToResponseDTO(attachment)
+	return &response, content, nil
+}
+
+func (s *attachmentService) DeleteAttachment(userID, taskID, attachmentID uint) error {
+	attachment, err := s.getTaskAttachment(userID, taskID, attachmentID, TaskActionEdit)
+	if err != nil {
+		return err
+	}
+
+	if err := s.attachmentRepo.Delete(attachment.ID); err != nil {
+		return err
+	}
+
+	s.deleteBlob(context.Background(), attachment.StorageKey)
+	return nil
+}
+
+func (s *attachmentService) getTaskAttachment(userID, taskID, attachmentID uint, action TaskAction) (*models.Attachment, error) {
+	task, err := s.permissions.GetTask(userID, taskID, action)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.attachmentRepo.GetByID(attachmentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+
+	if attachment.TaskID != task.ID {
+		return nil, ErrAttachmentNotFound
+	}
+
+	return attachment, nil
+}
+
+func (s *attachmentService) releaseReservation(attachment *models.Attachment) {
+	if err := s.attachmentRepo.Delete(attachment.ID); err != nil {
+		log.Printf("Failed to release attachment reservation %d: %v", attachment.ID, err)
+	}
+}
+
+func (s *attachmentService) deleteBlob(ctx context.Context, key string) {
+	if err := s.blobStore.Delete(ctx, key); err != nil {
+		log.Printf("Failed to delete attachment blob %s: %v", key, err)
+	}
+}
+
+type byteCounter int64
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
+
+func sniffAttachmentContentType(head []byte, filename string) string {
+	contentType := http.DetectContentType(head)
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
+	}
+
+	extension := strings.ToLower(path.Ext(filename))
+	switch contentType {
+	case "application/zip":
+		if officeType, ok := officeDocumentTypes[extension]; ok {
+			return officeType
+		}
+	case "text/plain":
+		if extension == ".csv" {
+			return "text/csv"
+		}
+	}
+	return contentType
+}
+
+func sanitizeAttachmentFilename(filename string) string {
+	filename = path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	filename = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, filename))
+
+	for len(filename) > maxAttachmentFilenameSize {
+		_, size := utf8.DecodeLastRuneInString(filename)
+		filename = filename[:len(filename)-size]
+	}
+
+	if filename == "" || filename == "." || filename == "/" {
+		return "attachment"
+	}
+	return filename
+}
\ No newline at end of file
@@ -2,6 +2,7 @@ package services
 
 import (
 	"task-api/models"
+	"task-api/repositories"
 	"task-api/utils"
 	"time"
 )
@@ -18,42 +19,154 @@ type LoginDTO struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
 type AuthResponseDTO struct {
-	User   UserResponseDTO  `json:"user"`
-	Tokens utils.TokenPair `json:"tokens"`
+	User        UserResponseDTO  `json:"user"`
+	Tokens      *utils.TokenPair `json:"tokens,omitempty"`
+	MFARequired bool             `json:"mfa_required,omitempty"`
+	MFAToken    string           `json:"mfa_token,omitempty"`
 }
 
 type UserResponseDTO struct {
-	ID        uint      `json:"id"`
-	Email     string    `json:"email"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID               uint      `json:"id"`
+	Email            string    `json:"email"`
+	FirstName        string    `json:"first_name"`
+	LastName         string    `json:"last_name"`
+	Role             string    `json:"role"`
+	IsActive         bool      `json:"is_active"`
+	EmailVerified    bool      `json:"email_verified"`
+	TwoFactorEnabled bool      `json:"two_factor_enabled"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type UserListResponseDTO struct {
+	Users      []UserResponseDTO             `json:"users"`
+	Pagination repositories.PaginationResult `json:"pagination"`
 }
 
 type RefreshTokenDTO struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
+type LogoutDTO struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type ForgotPasswordDTO struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordDTO struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8,max=100"`
+}
+
+type VerifyEmailDTO struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ResendVerificationDTO struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type UpdateProfileDTO struct {
+	FirstName *string `json:"first_name,omitempty" binding:"omitempty,min=1,max=50"`
+	LastName  *string `json:"last_name,omitempty" binding:"omitempty,min=1,max=50"`
+	Email     *string `json:"email,omitempty" binding:"omitempty,email"`
+}
+
+type ChangePasswordDTO struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8,max=100"`
+}
+
+type TOTPEnrollmentDTO struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type TOTPCodeDTO struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DisableTOTPDTO struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type VerifyMFADTO struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type RecoveryCodesDTO struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type SessionResponseDTO struct {
+	ID         uint      `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+type UpdateUserRoleDTO struct {
+	Role string `json:"role" binding:"required,oneof=user admin support"`
+}
+
 func (dto RegisterDTO) ToModel() *models.User {
 	return &models.User{
 		Email:     dto.Email,
 		FirstName: dto.FirstName,
 		LastName:  dto.LastName,
+		Role:      models.UserRoleUser,
 		IsActive:  true,
 	}
 }
 
 func UserToResponseDTO(user *models.User) UserResponseDTO {
 	return UserResponseDTO{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		IsActive:  user.IsActive,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:               user.ID,
+		Email:            user.Email,
+		FirstName:        user.FirstName,
+		LastName:         user.LastName,
+		Role:             string(user.Role),
+		IsActive:         user.IsActive,
+		EmailVerified:    user.IsEmailVerified(),
+		TwoFactorEnabled: user.IsTOTPEnabled(),
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+	}
+}
+
+func UsersToResponseDTO(users []models.User) []UserResponseDTO {
+	result := make([]UserResponseDTO, len(users))
+	for i, user := range users {
+		result[i] = UserToResponseDTO(&user)
+	}
+	return result
+}
+
+func SessionsToResponseDTO(sessions []models.Session, currentSessionID string) []SessionResponseDTO {
+	result := make([]SessionResponseDTO, len(sessions))
+	for i, session := range sessions {
+		result[i] = SessionResponseDTO{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    currentSessionID != "" && session.FamilyID == currentSessionID,
+		}
 	}
+	return result
 }
\ No newline at end of file
@@ -1,8 +1,22 @@
 package services
 
 type AuthService interface {
-	Register(dto RegisterDTO) (*AuthResponseDTO, error)
-	Login(dto LoginDTO) (*AuthResponseDTO, error)
-	RefreshToken(dto RefreshTokenDTO) (*AuthResponseDTO, error)
+	Register(dto RegisterDTO, client ClientInfo) (*AuthResponseDTO, error)
+	Login(dto LoginDTO, client ClientInfo) (*AuthResponseDTO, error)
+	RefreshToken(dto RefreshTokenDTO, client ClientInfo) (*AuthResponseDTO, error)
+	Logout(dto LogoutDTO) error
+	LogoutAll(userID uint) error
+	ForgotPassword(dto ForgotPasswordDTO) error
+	ResetPassword(dto ResetPasswordDTO) error
+	VerifyEmail(dto VerifyEmailDTO) (*UserResponseDTO, error)
+	ResendVerification(dto ResendVerificationDTO) error
 	GetUserProfile(userID uint) (*UserResponseDTO, error)
+	UpdateProfile(userID uint, dto UpdateProfileDTO) (*UserResponseDTO, error)
+	ChangePassword(userID uint, sessionID string, dto ChangePasswordDTO) error
+	EnrollTOTP(userID uint) (*TOTPEnrollmentDTO, error)
+	ConfirmTOTP(userID uint, dto TOTPCodeDTO) (*RecoveryCodesDTO, error)
+	DisableTOTP(userID uint, dto DisableTOTPDTO) error
+	VerifyMFA(dto VerifyMFADTO, client ClientInfo) (*AuthResponseDTO, error)
+	ListSessions(userID uint, currentSessionID string) ([]SessionResponseDTO, error)
+	RevokeSession(userID, sessionID uint) error
 }
\ No newline at end of file
@@ -2,8 +2,13 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"log"
 	"strings"
+	"time"
 
+	"task-api/mailer"
+	"task-api/models"
 	"task-api/repositories"
 	"task-api/utils"
 
@@ -11,24 +16,59 @@ import (
 )
 
 var (
-	ErrEmailAlreadyExists = errors.New("email already exists")
-	ErrInvalidCredentials = errors.New("invalid email or password")
-	ErrUserNotFound       = errors.New("user not found")
-	ErrUserInactive       = errors.New("user account is inactive")
-	ErrWeakPassword       = errors.New("password does not meet requirements")
+	ErrEmailAlreadyExists       = errors.New("email already exists")
+	ErrInvalidCredentials       = errors.New("invalid email or password")
+	ErrUserNotFound             = errors.New("user not found")
+	ErrUserInactive             = errors.New("user account is inactive")
+	ErrWeakPassword             = errors.New("password does not meet requirements")
+	ErrInvalidRefreshToken      = errors.New("invalid refresh token")
+	ErrRefreshTokenReused       = errors.New("refresh token reuse detected")
+	ErrInvalidResetToken        = errors.New("invalid or expired password reset token")
+	ErrEmailNotVerified         = errors.New("email address is not verified")
+	ErrInvalidVerificationToken = errors.New("invalid or expired email verification token")
+	ErrIncorrectPassword        = errors.New("current password is incorrect")
+	ErrMFAAlreadyEnabled        = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnrolled           = errors.New("two-factor enrollment has not been started")
+	ErrMFANotEnabled            = errors.New("two-factor authentication is not enabled")
+	ErrInvalidMFACode           = errors.New("invalid two-factor authentication code")
+	ErrInvalidMFAToken          = errors.New("invalid or expired two-factor session")
+	ErrSessionNotFound          = errors.New("session not found")
+)
+
+const (
+	DefaultPasswordResetTokenExpiry     = time.Hour
+	DefaultEmailVerificationTokenExpiry = 24 * time.Hour
+	RecoveryCodeCount                   = 10
 )
 
 type authService struct {
-	userRepo repositories.UserRepository
+	userRepo           repositories.UserRepository
+	refreshTokenRepo   repositories.RefreshTokenRepository
+	sessionRepo        repositories.SessionRepository
+	oneTimeTokenRepo   repositories.OneTimeTokenRepository
+	recoveryCodeRepo   repositories.RecoveryCodeRepository
+	mailer             mailer.Mailer
+	verificationPolicy EmailVerificationPolicy
+	throttle           *loginThrottle
 }
 
-func NewAuthService(userRepo repositories.UserRepository) AuthService {
+func NewAuthService(userRepo repositories.UserRepository, refreshTokenRepo repositories.RefreshTokenRepository, sessionRepo repositories.SessionRepository, oneTimeTokenRepo repositories.OneTimeTokenRepository, recoveryCodeRepo repositories.RecoveryCodeRepository, loginAttemptRepo repositories.LoginAttemptRepository, mail mailer.Mailer, verificationPolicy EmailVerificationPolicy, throttlePolicy LoginThrottlePolicy) AuthService {
 	return &authService{
-		userRepo: userRepo,
+		userRepo:           userRepo,
+		refreshTokenRepo:   refreshTokenRepo,
+		sessionRepo:        sessionRepo,
+		oneTimeTokenRepo:   oneTimeTokenRepo,
+		recoveryCodeRepo:   recoveryCodeRepo,
+		mailer:             mail,
+		verificationPolicy: verificationPolicy,
+		throttle: &loginThrottle{
+			attemptRepo: loginAttemptRepo,
+			policy:      throttlePolicy,
+		},
 	}
 }
 
-func (s *authService) Register(dto RegisterDTO) (*AuthResponseDTO, error) {
+func (s *authService) Register(dto RegisterDTO, client ClientInfo) (*AuthResponseDTO, error) {
 	if err := s.validateRegistration(dto); err != nil {
 		return nil, err
 	}
@@ -53,60 +93,323 @@ func (s *authService) Register(dto RegisterDTO) (*AuthResponseDTO, error) {
 		return nil, err
 	}
 
-	tokens, err := utils.GenerateTokenPair(user.ID, user.Email, user.FirstName, user.LastName)
+	if err := s.sendVerificationEmail(user); err != nil {
+		return nil, err
+	}
+
+	if s.verificationPolicy.BlocksLogin() {
+		return &AuthResponseDTO{
+			User: UserToResponseDTO(user),
+		}, nil
+	}
+
+	tokens, err := s.issueTokens(user, client)
 	if err != nil {
 		return nil, err
 	}
 
 	return &AuthResponseDTO{
 		User:   UserToResponseDTO(user),
-		Tokens: *tokens,
+		Tokens: tokens,
 	}, nil
 }
 
-func (s *authService) Login(dto LoginDTO) (*AuthResponseDTO, error) {
+func (s *authService) Login(dto LoginDTO, client ClientInfo) (*AuthResponseDTO, error) {
 	if err := s.validateLogin(dto); err != nil {
 		return nil, err
 	}
 
+	ipKey := ipThrottleKey(client.IPAddress)
+	if err := s.checkThrottle(ipKey, false); err != nil {
+		return nil, err
+	}
+
 	user, err := s.userRepo.GetByEmail(dto.Email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if err := s.throttle.recordFailure(ipKey, s.throttle.policy.MaxAttemptsPerIP); err != nil {
+				return nil, err
+			}
 			return nil, ErrInvalidCredentials
 		}
 		return nil, err
 	}
 
+	accountKey := accountThrottleKey(user.ID)
+	if err := s.checkThrottle(accountKey, true); err != nil {
+		return nil, err
+	}
+
 	if !user.IsActive {
 		return nil, ErrUserInactive
 	}
 
 	if !utils.CheckPasswordHash(dto.Password, user.Password) {
+		if err := s.throttle.recordFailure(ipKey, s.throttle.policy.MaxAttemptsPerIP); err != nil {
+			return nil, err
+		}
+		if err := s.throttle.recordFailure(accountKey, s.throttle.policy.MaxAttemptsPerAccount); err != nil {
+			return nil, err
+		}
 		return nil, ErrInvalidCredentials
 	}
 
-	tokens, err := utils.GenerateTokenPair(user.ID, user.Email, user.FirstName, user.LastName)
+	if s.verificationPolicy.BlocksLogin() && !user.IsEmailVerified() {
+		return nil, ErrEmailNotVerified
+	}
+
+	if user.IsTOTPEnabled() {
+		mfaToken, err := utils.GenerateMFAToken(user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &AuthResponseDTO{
+			User:        UserToResponseDTO(user),
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		}, nil
+	}
+
+	if err := s.throttle.reset(accountKey); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.issueTokens(user, client)
 	if err != nil {
 		return nil, err
 	}
 
 	return &AuthResponseDTO{
 		User:   UserToResponseDTO(user),
-		Tokens: *tokens,
+		Tokens: tokens,
 	}, nil
 }
 
-func (s *authService) RefreshToken(dto RefreshTokenDTO) (*AuthResponseDTO, error) {
-	claims, err := utils.ValidateRefreshToken(dto.RefreshToken)
+func (s *authService) RefreshToken(dto RefreshTokenDTO, client ClientInfo) (*AuthResponseDTO, error) {
+	current, err := s.refreshTokenRepo.GetByHash(utils.HashToken(dto.RefreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if current.IsRevoked() {
+		if current.ReplacedByID != nil {
+			if err := s.revokeSession(current.FamilyID); err != nil {
+				return nil, err
+			}
+			return nil, ErrRefreshTokenReused
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if current.IsExpired() {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.userRepo.GetByID(current.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+
+	accessToken, expiresIn, err := utils.GenerateAccessToken(user.ID, user.Email, user.FirstName, user.LastName, string(user.Role), current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, next, err := s.newRefreshToken(user.ID, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	rotated, err := s.refreshTokenRepo.Rotate(current, next)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		if err := s.revokeSession(current.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	if err := s.sessionRepo.Touch(current.FamilyID, client.IPAddress, truncate(client.UserAgent, 512), next.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	return &AuthResponseDTO{
+		User: UserToResponseDTO(user),
+		Tokens: &utils.TokenPair{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+			ExpiresIn:    int64(expiresIn.Seconds()),
+		},
+	}, nil
+}
+
+func (s *authService) Logout(dto LogoutDTO) error {
+	token, err := s.refreshTokenRepo.GetByHash(utils.HashToken(dto.RefreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidRefreshToken
+		}
+		return err
+	}
+
+	return s.revokeSession(token.FamilyID)
+}
+
+func (s *authService) LogoutAll(userID uint) error {
+	return s.revokeAllSessions(userID)
+}
+
+func (s *authService) ForgotPassword(dto ForgotPasswordDTO) error {
+	user, err := s.userRepo.GetByEmail(strings.TrimSpace(dto.Email))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if !user.IsActive {
+		return nil
+	}
+
+	if err := s.oneTimeTokenRepo.InvalidateForUser(user.ID, models.OneTimeTokenPasswordReset); err != nil {
+		return err
+	}
+
+	expiry := utils.GetEnvMinutes("PASSWORD_RESET_TOKEN_EXPIRY", DefaultPasswordResetTokenExpiry)
+	token, err := s.issueOneTimeToken(user.ID, models.OneTimeTokenPasswordReset, expiry)
+	if err != nil {
+		return err
+	}
+
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to choose a new password. It expires in %d minutes and can only be used once.\n\n%s/reset-password?token=%s\n\nIf you did not request a password reset you can ignore this email.\n",
+			user.FirstName, int(expiry.Minutes()), utils.GetEnvWithDefault("APP_BASE_URL", "http://localhost:8080"), token),
+	}
+
+	if err := s.mailer.Send(msg); err != nil {
+		log.Printf("Failed to send password reset email to user %d: %v", user.ID, err)
+	}
+
+	return nil
+}
+
+func (s *authService) ResetPassword(dto ResetPasswordDTO) error {
+	if err := s.validatePassword(dto.Password); err != nil {
+		return NewValidationError("password", err.Error())
+	}
+
+	token, err := s.oneTimeTokenRepo.GetByHash(models.OneTimeTokenPasswordReset, utils.HashToken(dto.Token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	if !token.IsUsable() {
+		return ErrInvalidResetToken
+	}
+
+	user, err := s.userRepo.GetByID(token.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	hashedPassword, err := utils.HashPassword(dto.Password)
+	if err != nil {
+		return err
+	}
+
+	used, err := s.oneTimeTokenRepo.ResetPassword(token.ID, user.ID, hashedPassword)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidResetToken
+	}
+
+	return s.revokeAllSessions(user.ID)
+}
+
+func (s *authService) VerifyEmail(dto VerifyEmailDTO) (*UserResponseDTO, error) {
+	token, err := s.oneTimeTokenRepo.GetByHash(models.OneTimeTokenEmailVerification, utils.HashToken(dto.Token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidVerificationToken
+		}
+		return nil, err
+	}
+
+	if !token.IsUsable() {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	user, err := s.userRepo.GetByID(token.UserID)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidVerificationToken
+		}
 		return nil, err
 	}
 
-	userID := uint(0)
-	if claims.Subject != "" {
-		if id, parseErr := utils.ExtractUserIDFromToken(dto.RefreshToken); parseErr == nil {
-			userID = id
+	used, err := s.oneTimeTokenRepo.MarkUsed(token.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	if !user.IsEmailVerified() {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+		if err := s.userRepo.Update(user); err != nil {
+			return nil, err
+		}
+	}
+
+	response := UserToResponseDTO(user)
+	return &response, nil
+}
+
+func (s *authService) ResendVerification(dto ResendVerificationDTO) error {
+	user, err := s.userRepo.GetByEmail(strings.TrimSpace(dto.Email))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
 		}
+		return err
+	}
+
+	if !user.IsActive || user.IsEmailVerified() {
+		return nil
+	}
+
+	return s.sendVerificationEmail(user)
+}
+
+func (s *authService) UpdateProfile(userID uint, dto UpdateProfileDTO) (*UserResponseDTO, error) {
+	if err := s.validateProfileUpdate(dto); err != nil {
+		return nil, err
 	}
 
 	user, err := s.userRepo.GetByID(userID)
@@ -117,21 +420,282 @@ func (s *authService) RefreshToken(dto RefreshTokenDTO) (*AuthResponseDTO, error
 		return nil, err
 	}
 
+	if dto.FirstName != nil {
+		user.FirstName = strings.TrimSpace(*dto.FirstName)
+	}
+	if dto.LastName != nil {
+		user.LastName = strings.TrimSpace(*dto.LastName)
+	}
+
+	emailChanged := false
+	if dto.Email != nil {
+		email := strings.TrimSpace(*dto.Email)
+		if !strings.EqualFold(email, user.Email) {
+			exists, err := s.userRepo.EmailExists(email)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				return nil, NewValidationError("email", "email address is already registered")
+			}
+
+			user.Email = email
+			user.EmailVerifiedAt = nil
+			emailChanged = true
+		}
+	}
+
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	if emailChanged {
+		if err := s.sendVerificationEmail(user); err != nil {
+			return nil, err
+		}
+	}
+
+	response := UserToResponseDTO(user)
+	return &response, nil
+}
+
+func (s *authService) ChangePassword(userID uint, sessionID string, dto ChangePasswordDTO) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	accountKey := accountThrottleKey(user.ID)
+	if err := s.checkThrottle(accountKey, true); err != nil {
+		return err
+	}
+
+	var validationErrors ValidationErrors
+
+	if !utils.CheckPasswordHash(dto.CurrentPassword, user.Password) {
+		if err := s.throttle.recordFailure(accountKey, s.throttle.policy.MaxAttemptsPerAccount); err != nil {
+			return err
+		}
+		validationErrors.AddError("current_password", ErrIncorrectPassword.Error())
+	}
+
+	if err := s.validatePassword(dto.NewPassword); err != nil {
+		validationErrors.AddError("new_password", err.Error())
+	} else if dto.NewPassword == dto.CurrentPassword {
+		validationErrors.AddError("new_password", "new password must differ from the current password")
+	}
+
+	if validationErrors.HasErrors() {
+		return validationErrors
+	}
+
+	hashedPassword, err := utils.HashPassword(dto.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	user.Password = hashedPassword
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	return s.revokeOtherSessions(user.ID, sessionID)
+}
+
+func (s *authService) EnrollTOTP(userID uint) (*TOTPEnrollmentDTO, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if user.IsTOTPEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := utils.EncryptSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	user.TOTPSecret = encrypted
+	user.TOTPLastStep = 0
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	issuer := utils.GetEnvWithDefault("MFA_ISSUER", "Task API")
+	return &TOTPEnrollmentDTO{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPProvisioningURI(secret, issuer, user.Email),
+	}, nil
+}
+
+func (s *authService) ConfirmTOTP(userID uint, dto TOTPCodeDTO) (*RecoveryCodesDTO, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if user.IsTOTPEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	if user.TOTPSecret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+
+	secret, err := utils.DecryptSecret(user.TOTPSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	step, ok := utils.ValidateTOTPCode(secret, dto.Code, time.Now())
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, err := s.generateRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	user.TOTPEnabledAt = &now
+	user.TOTPLastStep = step
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return &RecoveryCodesDTO{RecoveryCodes: codes}, nil
+}
+
+func (s *authService) DisableTOTP(userID uint, dto DisableTOTPDTO) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	if !user.IsTOTPEnabled() {
+		return ErrMFANotEnabled
+	}
+
+	if !utils.CheckPasswordHash(dto.Password, user.Password) {
+		return NewValidationError("password", ErrIncorrectPassword.Error())
+	}
+
+	ok, err := s.checkSecondFactor(user, dto.Code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	user.TOTPSecret = ""
+	user.TOTPEnabledAt = nil
+	user.TOTPLastStep = 0
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	return s.recoveryCodeRepo.DeleteForUser(user.ID)
+}
+
+func (s *authService) VerifyMFA(dto VerifyMFADTO, client ClientInfo) (*AuthResponseDTO, error) {
+	userID, err := utils.ValidateMFAToken(dto.MFAToken)
+	if err != nil {
+		return nil, ErrInvalidMFAToken
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidMFAToken
+		}
+		return nil, err
+	}
+
 	if !user.IsActive {
 		return nil, ErrUserInactive
 	}
 
-	tokens, err := utils.RefreshAccessToken(dto.RefreshToken, user.ID, user.Email, user.FirstName, user.LastName)
+	if !user.IsTOTPEnabled() {
+		return nil, ErrInvalidMFAToken
+	}
+
+	accountKey := accountThrottleKey(user.ID)
+	if err := s.checkThrottle(accountKey, true); err != nil {
+		return nil, err
+	}
+
+	ok, err := s.checkSecondFactor(user, dto.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := s.throttle.recordFailure(accountKey, s.throttle.policy.MaxAttemptsPerAccount); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidMFACode
+	}
+
+	if err := s.throttle.reset(accountKey); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.issueTokens(user, client)
 	if err != nil {
 		return nil, err
 	}
 
 	return &AuthResponseDTO{
 		User:   UserToResponseDTO(user),
-		Tokens: *tokens,
+		Tokens: tokens,
 	}, nil
 }
 
+func (s *authService) ListSessions(userID uint, currentSessionID string) ([]SessionResponseDTO, error) {
+	sessions, err := s.sessionRepo.ListActiveByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return SessionsToResponseDTO(sessions, currentSessionID), nil
+}
+
+func (s *authService) RevokeSession(userID, sessionID uint) error {
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+
+	if session.UserID != userID || !session.IsActive() {
+		return ErrSessionNotFound
+	}
+
+	return s.revokeSession(session.FamilyID)
+}
+
 func (s *authService) GetUserProfile(userID uint) (*UserResponseDTO, error) {
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
@@ -145,6 +709,216 @@ func (s *authService) GetUserProfile(userID uint) (*UserResponseDTO, error) {
 	return &response, nil
 }
 
+func (s *authService) issueTokens(user *models.User, client ClientInfo) (*utils.TokenPair, error) {
+	familyID, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, expiresIn, err := utils.GenerateAccessToken(user.ID, user.Email, user.FirstName, user.LastName, string(user.Role), familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, record, err := s.newRefreshToken(user.ID, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.refreshTokenRepo.Create(record); err != nil {
+		return nil, err
+	}
+
+	session := &models.Session{
+		UserID:     user.ID,
+		FamilyID:   familyID,
+		UserAgent:  truncate(client.UserAgent, 512),
+		IPAddress:  client.IPAddress,
+		LastSeenAt: time.Now(),
+		ExpiresAt:  record.ExpiresAt,
+	}
+
+	if err := s.sessionRepo.Create(session); err != nil {
+		return nil, err
+	}
+
+	return &utils.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(expiresIn.Seconds()),
+	}, nil
+}
+
+func (s *authService) revokeSession(familyID string) error {
+	if err := s.refreshTokenRepo.RevokeFamily(familyID); err != nil {
+		return err
+	}
+	return s.sessionRepo.RevokeByFamilyID(familyID)
+}
+
+func (s *authService) revokeAllSessions(userID uint) error {
+	if err := s.refreshTokenRepo.RevokeAllForUser(userID); err != nil {
+		return err
+	}
+	return s.sessionRepo.RevokeAllForUser(userID)
+}
+
+func (s *authService) revokeOtherSessions(userID uint, familyID string) error {
+	if err := s.refreshTokenRepo.RevokeAllForUserExcept(userID, familyID); err != nil {
+		return err
+	}
+	return s.sessionRepo.RevokeAllForUserExcept(userID, familyID)
+}
+
+func (s *authService) newRefreshToken(userID uint, familyID string) (string, *models.RefreshToken, error) {
+	token, err := utils.GenerateSecureToken(utils.DefaultTokenBytes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	record := &models.RefreshToken{
+		UserID:    userID,
+		TokenHash: utils.HashToken(token),
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(utils.GetRefreshTokenExpiry()),
+	}
+
+	return token, record, nil
+}
+
+func (s *authService) checkThrottle(identifier string, isAccount bool) error {
+	retryAfter, locked, err := s.throttle.check(identifier)
+	if err != nil {
+		return err
+	}
+
+	if locked && isAccount {
+		return AccountLockedError{RetryAfter: retryAfter}
+	}
+
+	if retryAfter > 0 {
+		return LoginThrottledError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+func (s *authService) checkSecondFactor(user *models.User, code string) (bool, error) {
+	secret, err := utils.DecryptSecret(user.TOTPSecret)
+	if err != nil {
+		return false, err
+	}
+
+	if step, ok := utils.ValidateTOTPCode(secret, code, time.Now()); ok {
+		if step <= user.TOTPLastStep {
+			return false, nil
+		}
+		user.TOTPLastStep = step
+		return true, s.userRepo.Update(user)
+	}
+
+	return s.recoveryCodeRepo.Consume(user.ID, utils.HashToken(normalizeRecoveryCode(code)))
+}
+
+func (s *authService) generateRecoveryCodes(userID uint) ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+
+	for i := range codes {
+		raw, err := utils.GenerateTOTPSecret()
+		if err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(raw[:5] + "-" + raw[5:10])
+		codes[i] = code
+		hashes[i] = utils.HashToken(normalizeRecoveryCode(code))
+	}
+
+	if err := s.recoveryCodeRepo.ReplaceForUser(userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func truncate(value string, maxLength int) string {
+	if len(value) <= maxLength {
+		return value
+	}
+	return value[:maxLength]
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
+
+func (s *authService) sendVerificationEmail(user *models.User) error {
+	if err := s.oneTimeTokenRepo.InvalidateForUser(user.ID, models.OneTimeTokenEmailVerification); err != nil {
+		return err
+	}
+
+	expiry := utils.GetEnvMinutes("EMAIL_VERIFICATION_TOKEN_EXPIRY", DefaultEmailVerificationTokenExpiry)
+	token, err := s.issueOneTimeToken(user.ID, models.OneTimeTokenEmailVerification, expiry)
+	if err != nil {
+		return err
+	}
+
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address by opening the link below. It expires in %d hours.\n\n%s/verify-email?token=%s\n",
+			user.FirstName, int(expiry.Hours()), utils.GetEnvWithDefault("APP_BASE_URL", "http://localhost:8080"), token),
+	}
+
+	if err := s.mailer.Send(msg); err != nil {
+		log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
+	}
+
+	return nil
+}
+
+func (s *authService) issueOneTimeToken(userID uint, purpose models.OneTimeTokenPurpose, expiry time.Duration) (string, error) {
+	token, err := utils.GenerateSecureToken(utils.DefaultTokenBytes)
+	if err != nil {
+		return "", err
+	}
+
+	record := &models.OneTimeToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(expiry),
+	}
+
+	if err := s.oneTimeTokenRepo.Create(record); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *authService) validateProfileUpdate(dto UpdateProfileDTO) error {
+	var validationErrors ValidationErrors
+
+	if dto.FirstName != nil && strings.TrimSpace(*dto.FirstName) == "" {
+		validationErrors.AddError("first_name", "first name cannot be empty")
+	}
+
+	if dto.LastName != nil && strings.TrimSpace(*dto.LastName) == "" {
+		validationErrors.AddError("last_name", "last name cannot be empty")
+	}
+
+	if dto.Email != nil && strings.TrimSpace(*dto.Email) == "" {
+		validationErrors.AddError("email", "email cannot be empty")
+	}
+
+	if validationErrors.HasErrors() {
+		return validationErrors
+	}
+
+	return nil
+}
+
 func (s *authService) validateRegistration(dto RegisterDTO) error {
 	var validationErrors ValidationErrors
 
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"task-api/models"
+	"task-api/repositories"
+	"time"
+)
+
+type CreateCommentDTO struct {
+	Body string `json:"body" binding:"required,min=1,max=10000"`
+}
+
+type UpdateCommentDTO struct {
+	Body string `json:"body" binding:"required,min=1,max=10000"`
+}
+
+type CommentAuthorDTO struct {
+	ID        uint   `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+type CommentResponseDTO struct {
+	ID        uint             `json:"id"`
+	TaskID    uint             `json:"task_id"`
+	Author    CommentAuthorDTO `json:"author"`
+	Body      string           `json:"body"`
+	Edited    bool             `json:"edited"`
+	EditedAt  *time.Time       `json:"edited_at,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+type CommentListResponseDTO struct {
+	Comments   []CommentResponseDTO          `json:"comments"`
+	Pagination repositories.PaginationResult `json:"pagination"`
+}
+
+func CommentToResponseDTO(comment *models.Comment) CommentResponseDTO {
+	return CommentResponseDTO{
+		ID:     comment.ID,
+		TaskID: comment.TaskID,
+		Author: CommentAuthorDTO{
+			ID:        comment.UserID,
+			FirstName: comment.User.FirstName,
+			LastName:  comment.User.LastName,
+		},
+		Body:      comment.Body,
+		Edited:    comment.IsEdited(),
+		EditedAt:  comment.EditedAt,
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+	}
+}
+
+func CommentsToResponseDTO(comments []models.Comment) []CommentResponseDTO {
+	result := make([]CommentResponseDTO, len(comments))
+	for i, comment := range comments {
+		result[i] = CommentToResponseDTO(&comment)
+	}
+	return result
+}
\ No newline at end of file
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"task-api/repositories"
+)
+
+type CommentService interface {
+	InWorkspace(workspaceID uint) CommentService
+	ListComments(userID, taskID uint, pagination repositories.PaginationParams) (*CommentListResponseDTO, error)
+	CreateComment(userID, taskID uint, dto CreateCommentDTO) (*CommentResponseDTO, error)
+	UpdateComment(userID, taskID, commentID uint, dto UpdateCommentDTO) (*CommentResponseDTO, error)
+	DeleteComment(userID, taskID, commentID uint) error
+}
\ No newline at end of file
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"task-api/models"
+	"task-api/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrNotCommentAuthor = errors.New("only the author can change this comment")
+)
+
+type commentService struct {
+	commentRepo repositories.CommentRepository
+	userRepo    repositories.UserRepository
+	permissions TaskPermissions
+}
+
+func NewCommentService(commentRepo repositories.CommentRepository, userRepo repositories.UserRepository, permissions TaskPermissions) CommentService {
+	return &commentService{
+		commentRepo: commentRepo,
+		userRepo:    userRepo,
+		permissions: permissions,
+	}
+}
+
+func (s *commentService) InWorkspace(workspaceID uint) CommentService {
+	scoped := *s
+	scoped.permissions = s.permissions.InWorkspace(workspaceID)
+	return &scoped
+}
+
+func (s *commentService) ListComments(userID, taskID uint, pagination repositories.PaginationParams) (*CommentListResponseDTO, error) {
+	if pagination.IsCursor() {
+		return nil, NewValidationError("pagination", "comments support page-based pagination only")
+	}
+
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, paginationResult, err := s.commentRepo.ListByTaskID(task.ID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommentListResponseDTO{
+		Comments:   CommentsToResponseDTO(comments),
+		Pagination: paginationResult,
+	}, nil
+}
+
+func (s *commentService) CreateComment(userID, taskID uint, dto CreateCommentDTO) (*CommentResponseDTO, error) {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	body := strings.TrimSpace(dto.Body)
+	if body == "" {
+		return nil, NewValidationError("body", "comment cannot be empty")
+	}
+
+	author, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &models.Comment{
+		TaskID: task.ID,
+		UserID: userID,
+		Body:   body,
+	}
+
+	if err := s.commentRepo.Create(comment); err != nil {
+		return nil, err
+	}
+	comment.User = *author
+
+	response := CommentToResponseDTO(comment)
+	return &response, nil
+}
+
+func (s *commentService) UpdateComment(userID, taskID, commentID uint, dto UpdateCommentDTO) (*CommentResponseDTO, error) {
+	comment, err := s.getAuthoredComment(userID, taskID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	body := strings.TrimSpace(dto.Body)
+	if body == "" {
+		return nil, NewValidationError("body", "comment cannot be empty")
+	}
+
+	if body != comment.Body {
+		now := time.Now()
+		comment.Body = body
+		comment.EditedAt = &now
+
+		if err := s.commentRepo.Update(comment); err != nil {
+			return nil, err
+		}
+	}
+
+	response := CommentToResponseDTO(comment)
+	return &response, nil
+}
+
+func (s *commentService) DeleteComment(userID, taskID, commentID uint) error {
+	comment, err := s.getAuthoredComment(userID, taskID, commentID)
+	if err != nil {
+		return err
+	}
+
+	return s.commentRepo.Delete(comment.ID)
+}
+
+func (s *commentService) getAuthoredComment(userID, taskID, commentID uint) (*models.Comment, error) {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentRepo.GetByID(commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	if comment.TaskID != task.ID {
+		return nil, ErrCommentNotFound
+	}
+
+	if comment.UserID != userID {
+		return nil, ErrNotCommentAuthor
+	}
+
+	return comment, nil
+}
\ No newline at end of file
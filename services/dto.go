@@ -11,14 +11,57 @@ type CreateTaskDTO struct {
 	Description string                `json:"description" binding:"max=1000"`
 	Priority    models.TaskPriority   `json:"priority" binding:"omitempty,oneof=low medium high"`
 	DueDate     *time.Time            `json:"due_date,omitempty"`
+	ParentID    *uint                 `json:"parent_id,omitempty"`
+	ProjectID   *uint                 `json:"project_id,omitempty"`
+	AssigneeID  *uint                 `json:"assignee_id,omitempty"`
+	LabelIDs    []uint                `json:"label_ids,omitempty"`
+	Labels      []string              `json:"labels,omitempty" binding:"omitempty,dive,min=1,max=50"`
+	RecurrenceRule     string         `json:"recurrence_rule,omitempty" binding:"max=255"`
+	RecurrenceTimezone string         `json:"recurrence_timezone,omitempty" binding:"max=64"`
 }
 
 type UpdateTaskDTO struct {
 	Title       *string               `json:"title,omitempty" binding:"omitempty,min=1,max=255"`
 	Description *string               `json:"description,omitempty" binding:"omitempty,max=1000"`
-	Status      *models.TaskStatus    `json:"status,omitempty" binding:"omitempty,oneof=pending in_progress completed cancelled"`
+	Status      *models.TaskStatus    `json:"status,omitempty" binding:"omitempty,min=1,max=20"`
 	Priority    *models.TaskPriority  `json:"priority,omitempty" binding:"omitempty,oneof=low medium high"`
 	DueDate     *time.Time            `json:"due_date,omitempty"`
+	ParentID    *uint                 `json:"parent_id,omitempty"`
+	ProjectID   *uint                 `json:"project_id,omitempty"`
+	AssigneeID  *uint                 `json:"assignee_id,omitempty"`
+	LabelIDs    *[]uint               `json:"label_ids,omitempty"`
+	Labels      *[]string             `json:"labels,omitempty" binding:"omitempty,dive,min=1,max=50"`
+	RecurrenceRule     *string        `json:"recurrence_rule,omitempty" binding:"omitempty,max=255"`
+	RecurrenceTimezone *string        `json:"recurrence_timezone,omitempty" binding:"omitempty,max=64"`
+
+	IgnoreBlockers bool `json:"-"`
+}
+
+type AddTaskDependencyDTO struct {
+	BlockedByID uint `json:"blocked_by_id" binding:"required"`
+}
+
+type TaskListQueryDTO struct {
+	Query           string   `form:"q"`
+	View            string   `form:"view"`
+	Status          []string `form:"status"`
+	Priority        []string `form:"priority"`
+	ParentID        string   `form:"parent_id"`
+	ProjectID       string   `form:"project_id"`
+	IncludeArchived bool     `form:"include_archived"`
+	Labels          []string `form:"labels"`
+	LabelMatch      string   `form:"label_match"`
+	DueAfter        string   `form:"due_after"`
+	DueBefore       string   `form:"due_before"`
+	Overdue         *bool    `form:"overdue"`
+	Blocked         *bool    `form:"blocked"`
+	CompletedAfter  string   `form:"completed_after"`
+	CompletedBefore string   `form:"completed_before"`
+	CreatedAfter    string   `form:"created_after"`
+	CreatedBefore   string   `form:"created_before"`
+	UpdatedAfter    string   `form:"updated_after"`
+	UpdatedBefore   string   `form:"updated_before"`
+	Sort            string   `form:"sort"`
 }
 
 type TaskResponseDTO struct {
@@ -26,12 +69,56 @@ type TaskResponseDTO struct {
 	Title       string               `json:"title"`
 	Description string               `json:"description"`
 	Status      models.TaskStatus    `json:"status"`
+	StatusCategory models.TaskStatusCategory `json:"status_category"`
 	Priority    models.TaskPriority  `json:"priority"`
 	DueDate     *time.Time           `json:"due_date,omitempty"`
 	CompletedAt *time.Time           `json:"completed_at,omitempty"`
 	CreatedAt   time.Time            `json:"created_at"`
 	UpdatedAt   time.Time            `json:"updated_at"`
 	UserID      uint                 `json:"user_id"`
+	WorkspaceID uint                 `json:"workspace_id"`
+	ParentID    *uint                `json:"parent_id,omitempty"`
+	ProjectID   *uint                `json:"project_id,omitempty"`
+	AssigneeID  *uint                `json:"assignee_id,omitempty"`
+	Labels      []LabelResponseDTO   `json:"labels"`
+	Progress    TaskProgressDTO      `json:"progress"`
+	CommentCount int64               `json:"comment_count"`
+	Blocked     bool                 `json:"blocked"`
+	BlockedBy   []uint               `json:"blocked_by,omitempty"`
+	Recurrence  *TaskRecurrenceDTO   `json:"recurrence,omitempty"`
+	NextOccurrence *TaskSummaryDTO   `json:"next_occurrence,omitempty"`
+	Warnings    []string             `json:"warnings,omitempty"`
+	Search      *TaskSearchMatchDTO  `json:"search,omitempty"`
+}
+
+type TaskSummaryDTO struct {
+	ID     uint              `json:"id"`
+	Title  string            `json:"title"`
+	Status models.TaskStatus `json:"status"`
+}
+
+type TaskRecurrenceDTO struct {
+	Rule       string `json:"rule"`
+	Timezone   string `json:"timezone"`
+	SeriesID   uint   `json:"series_id"`
+	Occurrence int    `json:"occurrence"`
+}
+
+type TaskDependenciesDTO struct {
+	BlockedBy []TaskSummaryDTO `json:"blocked_by"`
+	Blocking  []TaskSummaryDTO `json:"blocking"`
+}
+
+type TaskProgressDTO struct {
+	Completed int64 `json:"completed"`
+	Total     int64 `json:"total"`
+	Percent   int   `json:"percent"`
+}
+
+type TaskSearchMatchDTO struct {
+	Rank    float64 `json:"rank"`
+	Title   string  `json:"title"`
+	Snippet string  `json:"snippet"`
 }
 
 type TaskListResponseDTO struct {
@@ -46,29 +133,60 @@ func (dto CreateTaskDTO) ToModel(userID uint) *models.Task {
 		Status:      models.TaskStatusPending,
 		Priority:    dto.Priority,
 		DueDate:     dto.DueDate,
+		ParentID:    dto.ParentID,
 		UserID:      userID,
 	}
 
+	if dto.ProjectID != nil && *dto.ProjectID != 0 {
+		task.ProjectID = dto.ProjectID
+	}
+
+	if dto.AssigneeID != nil && *dto.AssigneeID != 0 {
+		task.AssigneeID = dto.AssigneeID
+	}
+
 	if task.Priority == "" {
 		task.Priority = models.TaskPriorityMedium
 	}
 
+	if dto.RecurrenceRule != "" {
+		task.RecurrenceRule = dto.RecurrenceRule
+		task.RecurrenceTimezone = dto.RecurrenceTimezone
+	}
+
 	return task
 }
 
 func TaskToResponseDTO(task *models.Task) TaskResponseDTO {
-	return TaskResponseDTO{
+	response := TaskResponseDTO{
 		ID:          task.ID,
 		Title:       task.Title,
 		Description: task.Description,
 		Status:      task.Status,
+		StatusCategory: task.StatusCategory,
 		Priority:    task.Priority,
 		DueDate:     task.DueDate,
 		CompletedAt: task.CompletedAt,
 		CreatedAt:   task.CreatedAt,
 		UpdatedAt:   task.UpdatedAt,
 		UserID:      task.UserID,
+		WorkspaceID: task.WorkspaceID,
+		ParentID:    task.ParentID,
+		ProjectID:   task.ProjectID,
+		AssigneeID:  task.AssigneeID,
+		Labels:      LabelsToResponseDTO(task.Labels),
+	}
+
+	if task.IsRecurring() && task.RecurrenceSeriesID != nil {
+		response.Recurrence = &TaskRecurrenceDTO{
+			Rule:       task.RecurrenceRule,
+			Timezone:   task.RecurrenceTimezone,
+			SeriesID:   *task.RecurrenceSeriesID,
+			Occurrence: task.RecurrenceIndex + 1,
+		}
 	}
+
+	return response
 }
 
 func TasksToResponseDTO(tasks []models.Task) []TaskResponseDTO {
@@ -77,4 +195,31 @@ func TasksToResponseDTO(tasks []models.Task) []TaskResponseDTO {
 		result[i] = TaskToResponseDTO(&task)
 	}
 	return result
+}
+
+func TaskProgressToDTO(progress repositories.TaskProgress) TaskProgressDTO {
+	dto := TaskProgressDTO{
+		Completed: progress.Completed,
+		Total:     progress.Total,
+	}
+	if progress.Total > 0 {
+		dto.Percent = int(progress.Completed * 100 / progress.Total)
+	}
+	return dto
+}
+
+func TaskToSummaryDTO(task *models.Task) TaskSummaryDTO {
+	return TaskSummaryDTO{
+		ID:     task.ID,
+		Title:  task.Title,
+		Status: task.Status,
+	}
+}
+
+func TasksToSummaryDTO(tasks []models.Task) []TaskSummaryDTO {
+	result := make([]TaskSummaryDTO, len(tasks))
+	for i, task := range tasks {
+		result[i] = TaskToSummaryDTO(&task)
+	}
+	return result
 }
\ No newline at end of file
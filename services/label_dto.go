@@ -0,0 +1,42 @@
+package services
+
+import (
+	"task-api/models"
+	"time"
+)
+
+type CreateLabelDTO struct {
+	Name  string `json:"name" binding:"required,min=1,max=50"`
+	Color string `json:"color" binding:"omitempty,hexcolor"`
+}
+
+type UpdateLabelDTO struct {
+	Name  *string `json:"name,omitempty" binding:"omitempty,min=1,max=50"`
+	Color *string `json:"color,omitempty" binding:"omitempty,hexcolor"`
+}
+
+type LabelResponseDTO struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func LabelToResponseDTO(label *models.Label) LabelResponseDTO {
+	return LabelResponseDTO{
+		ID:        label.ID,
+		Name:      label.Name,
+		Color:     label.Color,
+		CreatedAt: label.CreatedAt,
+		UpdatedAt: label.UpdatedAt,
+	}
+}
+
+func LabelsToResponseDTO(labels []models.Label) []LabelResponseDTO {
+	result := make([]LabelResponseDTO, len(labels))
+	for i, label := range labels {
+		result[i] = LabelToResponseDTO(&label)
+	}
+	return result
+}
\ No newline at end of file
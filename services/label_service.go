@@ -0,0 +1,9 @@
+package services
+
+type LabelService interface {
+	CreateLabel(userID uint, dto CreateLabelDTO) (*LabelResponseDTO, error)
+	ListLabels(userID uint) ([]LabelResponseDTO, error)
+	GetLabel(userID, labelID uint) (*LabelResponseDTO, error)
+	UpdateLabel(userID, labelID uint, dto UpdateLabelDTO) (*LabelResponseDTO, error)
+	DeleteLabel(userID, labelID uint) error
+}
\ No newline at end of file
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"task-api/models"
+	"task-api/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrLabelNotFound  = errors.New("label not found")
+	ErrLabelNameTaken = errors.New("label name already in use")
+)
+
+const maxLabelNameLength = 50
+
+type labelService struct {
+	labelRepo repositories.LabelRepository
+}
+
+func NewLabelService(labelRepo repositories.LabelRepository) LabelService {
+	return &labelService{
+		labelRepo: labelRepo,
+	}
+}
+
+func (s *labelService) CreateLabel(userID uint, dto CreateLabelDTO) (*LabelResponseDTO, error) {
+	name := strings.TrimSpace(dto.Name)
+	if err := validateLabelName("name", name); err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureNameAvailable(userID, name, 0); err != nil {
+		return nil, err
+	}
+
+	label := &models.Label{
+		UserID: userID,
+		Name:   name,
+		Color:  normalizeLabelColor(dto.Color),
+	}
+
+	if err := s.labelRepo.Create(label); err != nil {
+		return nil, err
+	}
+
+	response := LabelToResponseDTO(label)
+	return &response, nil
+}
+
+func (s *labelService) ListLabels(userID uint) ([]LabelResponseDTO, error) {
+	labels, err := s.labelRepo.ListByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return LabelsToResponseDTO(labels), nil
+}
+
+func (s *labelService) GetLabel(userID, labelID uint) (*LabelResponseDTO, error) {
+	label, err := s.getOwnedLabel(userID, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := LabelToResponseDTO(label)
+	return &response, nil
+}
+
+func (s *labelService) UpdateLabel(userID, labelID uint, dto UpdateLabelDTO) (*LabelResponseDTO, error) {
+	label, err := s.getOwnedLabel(userID, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Name != nil {
+		name := strings.TrimSpace(*dto.Name)
+		if err := validateLabelName("name", name); err != nil {
+			return nil, err
+		}
+		if name != label.Name {
+			if err := s.ensureNameAvailable(userID, name, label.ID); err != nil {
+				return nil, err
+			}
+		}
+		label.Name = name
+	}
+
+	if dto.Color != nil {
+		label.Color = normalizeLabelColor(*dto.Color)
+	}
+
+	if err := s.labelRepo.Update(label); err != nil {
+		return nil, err
+	}
+
+	response := LabelToResponseDTO(label)
+	return &response, nil
+}
+
+func (s *labelService) DeleteLabel(userID, labelID uint) error {
+	label, err := s.getOwnedLabel(userID, labelID)
+	if err != nil {
+		return err
+	}
+
+	return s.labelRepo.Delete(label.ID, userID)
+}
+
+func (s *labelService) getOwnedLabel(userID, labelID uint) (*models.Label, error) {
+	label, err := s.labelRepo.GetByID(labelID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLabelNotFound
+		}
+		return nil, err
+	}
+
+	if label.UserID != userID {
+		return nil, ErrLabelNotFound
+	}
+
+	return label, nil
+}
+
+func (s *labelService) ensureNameAvailable(userID uint, name string, exceptID uint) error {
+	existing, err := s.labelRepo.GetByName(userID, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if existing.ID != exceptID {
+		return ErrLabelNameTaken
+	}
+
+	return nil
+}
+
+func validateLabelName(field, name string) error {
+	if name == "" {
+		return NewValidationError(field, "label name is required")
+	}
+	if len(name) > maxLabelNameLength {
+		return NewValidationError(field, "label name must be at most 50 characters")
+	}
+	if strings.Contains(name, ",") {
+		return NewValidationError(field, "label name cannot contain commas")
+	}
+	return nil
+}
+
+func normalizeLabelColor(color string) string {
+	if color == "" {
+		return models.DefaultLabelColor
+	}
+	return strings.ToLower(color)
+}
\ No newline at end of file
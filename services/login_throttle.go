@@ -0,0 +1,89 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"task-api/repositories"
+
+	"gorm.io/gorm"
+)
+
+type AccountLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e AccountLockedError) Error() string {
+	return fmt.Sprintf("account is temporarily locked, retry after %s", e.RetryAfter.Round(time.Second))
+}
+
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e LoginThrottledError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, retry after %s", e.RetryAfter.Round(time.Second))
+}
+
+type loginThrottle struct {
+	attemptRepo repositories.LoginAttemptRepository
+	policy      LoginThrottlePolicy
+}
+
+func accountThrottleKey(userID uint) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+func ipThrottleKey(ip string) string {
+	return "ip:" + ip
+}
+
+func (t *loginThrottle) check(identifier string) (time.Duration, bool, error) {
+	attempt, err := t.attemptRepo.GetByIdentifier(identifier)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	now := time.Now()
+
+	if attempt.IsLocked(now) {
+		return attempt.LockedUntil.Sub(now), true, nil
+	}
+
+	if attempt.LastFailedAt == nil || now.Sub(*attempt.LastFailedAt) > t.policy.LockoutDuration || attempt.LockedUntil != nil {
+		return 0, false, t.attemptRepo.Reset(identifier)
+	}
+
+	wait := attempt.LastFailedAt.Add(t.policy.Backoff(attempt.FailedAttempts)).Sub(now)
+	if wait > 0 {
+		return wait, false, nil
+	}
+
+	return 0, false, nil
+}
+
+func (t *loginThrottle) recordFailure(identifier string, maxAttempts int) error {
+	now := time.Now()
+
+	attempt, err := t.attemptRepo.RecordFailure(identifier, now)
+	if err != nil {
+		return err
+	}
+
+	if attempt.FailedAttempts >= maxAttempts {
+		return t.attemptRepo.Lock(identifier, now.Add(t.policy.LockoutDuration))
+	}
+
+	return nil
+}
+
+// reset clears an account's failures once a login has fully succeeded. Client
+// IP counters are never reset on success, so an attacker cannot clear them by
+// logging into an account of their own; they only expire.
+func (t *loginThrottle) reset(identifier string) error {
+	return t.attemptRepo.Reset(identifier)
+}
\ No newline at end of file
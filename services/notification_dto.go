@@ -0,0 +1,43 @@
+package services
+
+import (
+	"task-api/models"
+	"task-api/repositories"
+	"time"
+)
+
+type NotificationResponseDTO struct {
+	ID        uint       `json:"id"`
+	TaskID    *uint      `json:"task_id,omitempty"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type NotificationListResponseDTO struct {
+	Notifications []NotificationResponseDTO     `json:"notifications"`
+	Unread        int64                         `json:"unread"`
+	Pagination    repositories.PaginationResult `json:"pagination"`
+}
+
+func NotificationToResponseDTO(notification *models.Notification) NotificationResponseDTO {
+	return NotificationResponseDTO{
+		ID:        notification.ID,
+		TaskID:    notification.TaskID,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Read:      notification.IsRead(),
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+func NotificationsToResponseDTO(notifications []models.Notification) []NotificationResponseDTO {
+	result := make([]NotificationResponseDTO, len(notifications))
+	for i, notification := range notifications {
+		result[i] = NotificationToResponseDTO(&notification)
+	}
+	return result
+}
\ No newline at end of file
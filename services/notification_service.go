@@ -0,0 +1,11 @@
+package services
+
+import (
+	"task-api/repositories"
+)
+
+type NotificationService interface {
+	ListNotifications(userID uint, unreadOnly bool, pagination repositories.PaginationParams) (*NotificationListResponseDTO, error)
+	MarkRead(userID, notificationID uint) (*NotificationResponseDTO, error)
+	MarkAllRead(userID uint) error
+}
\ No newline at end of file
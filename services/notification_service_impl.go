@@ -0,0 +1,75 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"task-api/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+type notificationService struct {
+	notificationRepo repositories.NotificationRepository
+}
+
+func NewNotificationService(notificationRepo repositories.NotificationRepository) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+	}
+}
+
+func (s *notificationService) ListNotifications(userID uint, unreadOnly bool, pagination repositories.PaginationParams) (*NotificationListResponseDTO, error) {
+	if pagination.IsCursor() {
+		return nil, NewValidationError("pagination", "notifications support page-based pagination only")
+	}
+
+	notifications, paginationResult, err := s.notificationRepo.ListByUserID(userID, unreadOnly, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NotificationListResponseDTO{
+		Notifications: NotificationsToResponseDTO(notifications),
+		Unread:        unread,
+		Pagination:    paginationResult,
+	}, nil
+}
+
+func (s *notificationService) MarkRead(userID, notificationID uint) (*NotificationResponseDTO, error) {
+	notification, err := s.notificationRepo.GetByID(notificationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, err
+	}
+
+	if notification.UserID != userID {
+		return nil, ErrNotificationNotFound
+	}
+
+	if !notification.IsRead() {
+		now := time.Now()
+		if err := s.notificationRepo.MarkRead(notification.ID, now); err != nil {
+			return nil, err
+		}
+		notification.ReadAt = &now
+	}
+
+	response := NotificationToResponseDTO(notification)
+	return &response, nil
+}
+
+func (s *notificationService) MarkAllRead(userID uint) error {
+	return s.notificationRepo.MarkAllRead(userID, time.Now())
+}
\ No newline at end of file
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"task-api/models"
+	"time"
+)
+
+type CreatePersonalAccessTokenDTO struct {
+	Name      string     `json:"name" binding:"required,min=1,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,dive,oneof=tasks:read tasks:write workspaces:admin admin"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type UpdatePersonalAccessTokenDTO struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+type PersonalAccessTokenResponseDTO struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type PersonalAccessTokenCreatedDTO struct {
+	PersonalAccessTokenResponseDTO
+	Token string `json:"token"`
+}
+
+func PersonalAccessTokenToResponseDTO(token *models.PersonalAccessToken) PersonalAccessTokenResponseDTO {
+	return PersonalAccessTokenResponseDTO{
+		ID:          token.ID,
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      token.ScopeList(),
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+		CreatedAt:   token.CreatedAt,
+	}
+}
+
+func PersonalAccessTokensToResponseDTO(tokens []models.PersonalAccessToken) []PersonalAccessTokenResponseDTO {
+	result := make([]PersonalAccessTokenResponseDTO, len(tokens))
+	for i, token := range tokens {
+		result[i] = PersonalAccessTokenToResponseDTO(&token)
+	}
+	return result
+}
\ No newline at end of file
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"task-api/utils"
+)
+
+type PersonalAccessTokenService interface {
+	CreateToken(userID uint, dto CreatePersonalAccessTokenDTO) (*PersonalAccessTokenCreatedDTO, error)
+	ListTokens(userID uint) ([]PersonalAccessTokenResponseDTO, error)
+	GetToken(userID, tokenID uint) (*PersonalAccessTokenResponseDTO, error)
+	UpdateToken(userID, tokenID uint, dto UpdatePersonalAccessTokenDTO) (*PersonalAccessTokenResponseDTO, error)
+	RevokeToken(userID, tokenID uint) error
+	AuthenticatePersonalAccessToken(token string) (*utils.JWTClaims, []string, error)
+}
\ No newline at end of file
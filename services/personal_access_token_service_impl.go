@@ -0,0 +1,223 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"task-api/models"
+	"task-api/repositories"
+	"task-api/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPersonalAccessTokenNotFound = errors.New("personal access token not found")
+)
+
+const (
+	lastUsedUpdateInterval = time.Minute
+)
+
+type personalAccessTokenService struct {
+	tokenRepo repositories.PersonalAccessTokenRepository
+	userRepo  repositories.UserRepository
+}
+
+func NewPersonalAccessTokenService(tokenRepo repositories.PersonalAccessTokenRepository, userRepo repositories.UserRepository) PersonalAccessTokenService {
+	return &personalAccessTokenService{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+	}
+}
+
+func (s *personalAccessTokenService) CreateToken(userID uint, dto CreatePersonalAccessTokenDTO) (*PersonalAccessTokenCreatedDTO, error) {
+	if err := s.validateCreateToken(dto); err != nil {
+		return nil, err
+	}
+
+	raw, err := utils.GenerateSecureToken(utils.DefaultTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := utils.PersonalAccessTokenPrefix + raw
+
+	token := &models.PersonalAccessToken{
+		UserID:      userID,
+		Name:        strings.TrimSpace(dto.Name),
+		TokenHash:   utils.HashToken(plaintext),
+		TokenPrefix: plaintext[:len(utils.PersonalAccessTokenPrefix)+6],
+		Scopes:      strings.Join(uniqueScopes(dto.Scopes), ","),
+		ExpiresAt:   dto.ExpiresAt,
+	}
+
+	if err := s.tokenRepo.Create(token); err != nil {
+		return nil, err
+	}
+
+	return &PersonalAccessTokenCreatedDTO{
+		PersonalAccessTokenResponseDTO: PersonalAccessTokenToResponseDTO(token),
+		Token:                          plaintext,
+	}, nil
+}
+
+func (s *personalAccessTokenService) ListTokens(userID uint) ([]PersonalAccessTokenResponseDTO, error) {
+	tokens, err := s.tokenRepo.ListByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return PersonalAccessTokensToResponseDTO(tokens), nil
+}
+
+func (s *personalAccessTokenService) GetToken(userID, tokenID uint) (*PersonalAccessTokenResponseDTO, error) {
+	token, err := s.getOwnedToken(userID, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := PersonalAccessTokenToResponseDTO(token)
+	return &response, nil
+}
+
+func (s *personalAccessTokenService) UpdateToken(userID, tokenID uint, dto UpdatePersonalAccessTokenDTO) (*PersonalAccessTokenResponseDTO, error) {
+	if strings.TrimSpace(dto.Name) == "" {
+		return nil, NewValidationError("name", "name is required")
+	}
+
+	token, err := s.getOwnedToken(userID, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	token.Name = strings.TrimSpace(dto.Name)
+	if err := s.tokenRepo.Update(token); err != nil {
+		return nil, err
+	}
+
+	response := PersonalAccessTokenToResponseDTO(token)
+	return &response, nil
+}
+
+func (s *personalAccessTokenService) RevokeToken(userID, tokenID uint) error {
+	token, err := s.getOwnedToken(userID, tokenID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	token.RevokedAt = &now
+	return s.tokenRepo.Update(token)
+}
+
+func (s *personalAccessTokenService) AuthenticatePersonalAccessToken(plaintext string) (*utils.JWTClaims, []string, error) {
+	token, err := s.tokenRepo.GetByHash(utils.HashToken(plaintext))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, utils.ErrInvalidToken
+		}
+		return nil, nil, err
+	}
+
+	if token.IsRevoked() {
+		return nil, nil, utils.ErrInvalidToken
+	}
+
+	if token.IsExpired() {
+		return nil, nil, utils.ErrExpiredToken
+	}
+
+	user, err := s.userRepo.GetByID(token.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, utils.ErrInvalidToken
+		}
+		return nil, nil, err
+	}
+
+	if !user.IsActive {
+		return nil, nil, utils.ErrInvalidToken
+	}
+
+	if token.LastUsedAt == nil || time.Since(*token.LastUsedAt) > lastUsedUpdateInterval {
+		if err := s.tokenRepo.TouchLastUsed(token.ID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	claims := &utils.JWTClaims{
+		UserID:    user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Role:      string(user.Role),
+	}
+
+	return claims, token.ScopeList(), nil
+}
+
+func (s *personalAccessTokenService) getOwnedToken(userID, tokenID uint) (*models.PersonalAccessToken, error) {
+	token, err := s.tokenRepo.GetByID(tokenID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPersonalAccessTokenNotFound
+		}
+		return nil, err
+	}
+
+	if token.UserID != userID || token.IsRevoked() {
+		return nil, ErrPersonalAccessTokenNotFound
+	}
+
+	return token, nil
+}
+
+func (s *personalAccessTokenService) validateCreateToken(dto CreatePersonalAccessTokenDTO) error {
+	var validationErrors ValidationErrors
+
+	if strings.TrimSpace(dto.Name) == "" {
+		validationErrors.AddError("name", "name is required")
+	}
+
+	if len(dto.Scopes) == 0 {
+		validationErrors.AddError("scopes", "at least one scope is required")
+	}
+
+	for _, scope := range dto.Scopes {
+		if !isValidScope(scope) {
+			validationErrors.AddError("scopes", "unknown scope: "+scope)
+		}
+	}
+
+	if dto.ExpiresAt != nil && dto.ExpiresAt.Before(time.Now()) {
+		validationErrors.AddError("expires_at", "expiry cannot be in the past")
+	}
+
+	if validationErrors.HasErrors() {
+		return validationErrors
+	}
+
+	return nil
+}
+
+func isValidScope(scope string) bool {
+	for _, valid := range models.ValidTokenScopes {
+		if scope == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	return result
+}
\ No newline at end of file
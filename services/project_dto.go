@@ -0,0 +1,54 @@
+package services
+
+import (
+	"task-api/models"
+	"time"
+)
+
+type CreateProjectDTO struct {
+	Name        string `json:"name" binding:"required,min=1,max=100"`
+	Description string `json:"description" binding:"max=1000"`
+	Color       string `json:"color" binding:"omitempty,hexcolor"`
+	SortOrder   *int   `json:"sort_order,omitempty"`
+}
+
+type UpdateProjectDTO struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description,omitempty" binding:"omitempty,max=1000"`
+	Color       *string `json:"color,omitempty" binding:"omitempty,hexcolor"`
+	SortOrder   *int    `json:"sort_order,omitempty"`
+}
+
+type ProjectResponseDTO struct {
+	ID          uint      `json:"id"`
+	WorkspaceID uint      `json:"workspace_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Color       string    `json:"color"`
+	Archived    bool      `json:"archived"`
+	SortOrder   int       `json:"sort_order"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func ProjectToResponseDTO(project *models.Project) ProjectResponseDTO {
+	return ProjectResponseDTO{
+		ID:          project.ID,
+		WorkspaceID: project.WorkspaceID,
+		Name:        project.Name,
+		Description: project.Description,
+		Color:       project.Color,
+		Archived:    project.Archived,
+		SortOrder:   project.SortOrder,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.UpdatedAt,
+	}
+}
+
+func ProjectsToResponseDTO(projects []models.Project) []ProjectResponseDTO {
+	result := make([]ProjectResponseDTO, len(projects))
+	for i, project := range projects {
+		result[i] = ProjectToResponseDTO(&project)
+	}
+	return result
+}
\ No newline at end of file
@@ -0,0 +1,12 @@
+package services
+
+type ProjectService interface {
+	InWorkspace(workspaceID uint) ProjectService
+	CreateProject(userID uint, dto CreateProjectDTO) (*ProjectResponseDTO, error)
+	ListProjects(userID uint, includeArchived bool) ([]ProjectResponseDTO, error)
+	GetProject(userID, projectID uint) (*ProjectResponseDTO, error)
+	UpdateProject(userID, projectID uint, dto UpdateProjectDTO) (*ProjectResponseDTO, error)
+	ArchiveProject(userID, projectID uint) (*ProjectResponseDTO, error)
+	UnarchiveProject(userID, projectID uint) (*ProjectResponseDTO, error)
+	DeleteProject(userID, projectID uint) error
+}
\ No newline at end of file
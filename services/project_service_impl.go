@@ -0,0 +1,167 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"task-api/models"
+	"task-api/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrProjectNotFound = errors.New("project not found")
+	ErrProjectArchived = errors.New("project is archived")
+)
+
+type projectService struct {
+	projectRepo repositories.ProjectRepository
+}
+
+func NewProjectService(projectRepo repositories.ProjectRepository) ProjectService {
+	return &projectService{
+		projectRepo: projectRepo,
+	}
+}
+
+func (s *projectService) InWorkspace(workspaceID uint) ProjectService {
+	return &projectService{
+		projectRepo: s.projectRepo.InWorkspace(workspaceID),
+	}
+}
+
+func (s *projectService) CreateProject(userID uint, dto CreateProjectDTO) (*ProjectResponseDTO, error) {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, NewValidationError("name", "name is required")
+	}
+
+	project := &models.Project{
+		UserID:      userID,
+		Name:        name,
+		Description: dto.Description,
+		Color:       models.DefaultProjectColor,
+	}
+
+	if dto.Color != "" {
+		project.Color = strings.ToLower(dto.Color)
+	}
+
+	if dto.SortOrder != nil {
+		project.SortOrder = *dto.SortOrder
+	} else {
+		next, err := s.projectRepo.NextSortOrder(userID)
+		if err != nil {
+			return nil, err
+		}
+		project.SortOrder = next
+	}
+
+	if err := s.projectRepo.Create(project); err != nil {
+		return nil, err
+	}
+
+	response := ProjectToResponseDTO(project)
+	return &response, nil
+}
+
+func (s *projectService) ListProjects(userID uint, includeArchived bool) ([]ProjectResponseDTO, error) {
+	projects, err := s.projectRepo.ListByUserID(userID, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	return ProjectsToResponseDTO(projects), nil
+}
+
+func (s *projectService) GetProject(userID, projectID uint) (*ProjectResponseDTO, error) {
+	project, err := s.getOwnedProject(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := ProjectToResponseDTO(project)
+	return &response, nil
+}
+
+func (s *projectService) UpdateProject(userID, projectID uint, dto UpdateProjectDTO) (*ProjectResponseDTO, error) {
+	project, err := s.getOwnedProject(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Name != nil {
+		name := strings.TrimSpace(*dto.Name)
+		if name == "" {
+			return nil, NewValidationError("name", "name cannot be empty")
+		}
+		project.Name = name
+	}
+	if dto.Description != nil {
+		project.Description = *dto.Description
+	}
+	if dto.Color != nil {
+		project.Color = strings.ToLower(*dto.Color)
+	}
+	if dto.SortOrder != nil {
+		project.SortOrder = *dto.SortOrder
+	}
+
+	if err := s.projectRepo.Update(project); err != nil {
+		return nil, err
+	}
+
+	response := ProjectToResponseDTO(project)
+	return &response, nil
+}
+
+func (s *projectService) ArchiveProject(userID, projectID uint) (*ProjectResponseDTO, error) {
+	return s.setArchived(userID, projectID, true)
+}
+
+func (s *projectService) UnarchiveProject(userID, projectID uint) (*ProjectResponseDTO, error) {
+	return s.setArchived(userID, projectID, false)
+}
+
+func (s *projectService) DeleteProject(userID, projectID uint) error {
+	project, err := s.getOwnedProject(userID, projectID)
+	if err != nil {
+		return err
+	}
+
+	return s.projectRepo.Delete(project.ID, userID)
+}
+
+func (s *projectService) setArchived(userID, projectID uint, archived bool) (*ProjectResponseDTO, error) {
+	project, err := s.getOwnedProject(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.Archived != archived {
+		project.Archived = archived
+		if err := s.projectRepo.Update(project); err != nil {
+			return nil, err
+		}
+	}
+
+	response := ProjectToResponseDTO(project)
+	return &response, nil
+}
+
+func (s *projectService) getOwnedProject(userID, projectID uint) (*models.Project, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	if project.UserID != userID {
+		return nil, ErrProjectNotFound
+	}
+
+	return project, nil
+}
\ No newline at end of file
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"task-api/models"
+	"time"
+)
+
+type CreateReminderDTO struct {
+	Channel       models.ReminderChannel `json:"channel" binding:"required,oneof=email webhook in_app"`
+	OffsetMinutes *int                   `json:"offset_minutes,omitempty" binding:"omitempty,min=0,max=43200"`
+	RemindAt      *time.Time             `json:"remind_at,omitempty"`
+	WebhookURL    string                 `json:"webhook_url,omitempty" binding:"omitempty,url,max=2048"`
+}
+
+type ReminderResponseDTO struct {
+	ID            uint                   `json:"id"`
+	TaskID        uint                   `json:"task_id"`
+	Channel       models.ReminderChannel `json:"channel"`
+	WebhookURL    string                 `json:"webhook_url,omitempty"`
+	OffsetMinutes *int                   `json:"offset_minutes,omitempty"`
+	RemindAt      time.Time              `json:"remind_at"`
+	Status        models.ReminderStatus  `json:"status"`
+	Attempts      int                    `json:"attempts"`
+	LastError     string                 `json:"last_error,omitempty"`
+	SentAt        *time.Time             `json:"sent_at,omitempty"`
+	CreatedAt     time.Time              `json:"created_at"`
+}
+
+func ReminderToResponseDTO(reminder *models.Reminder) ReminderResponseDTO {
+	return ReminderResponseDTO{
+		ID:            reminder.ID,
+		TaskID:        reminder.TaskID,
+		Channel:       reminder.Channel,
+		WebhookURL:    reminder.WebhookURL,
+		OffsetMinutes: reminder.OffsetMinutes,
+		RemindAt:      reminder.RemindAt,
+		Status:        reminder.Status,
+		Attempts:      reminder.Attempts,
+		LastError:     reminder.LastError,
+		SentAt:        reminder.SentAt,
+		CreatedAt:     reminder.CreatedAt,
+	}
+}
+
+func RemindersToResponseDTO(reminders []models.Reminder) []ReminderResponseDTO {
+	result := make([]ReminderResponseDTO, len(reminders))
+	for i, reminder := range reminders {
+		result[i] = ReminderToResponseDTO(&reminder)
+	}
+	return result
+}
\ No newline at end of file
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"task-api/mailer"
+	"task-api/models"
+	"task-api/repositories"
+)
+
+type ReminderDelivery struct {
+	Reminder *models.Reminder
+	Task     *models.Task
+	User     *models.User
+}
+
+type ReminderNotifier interface {
+	Notify(ctx context.Context, delivery ReminderDelivery) error
+}
+
+// ReminderDeliveryError is a delivery failure whose Message is safe to show
+// to the reminder's owner. Err keeps the underlying cause for the logs.
+type ReminderDeliveryError struct {
+	Message string
+	Err     error
+}
+
+func (e ReminderDeliveryError) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+func (e ReminderDeliveryError) Unwrap() error {
+	return e.Err
+}
+
+func (d ReminderDelivery) Subject() string {
+	return "Reminder: " + d.Task.Title
+}
+
+func (d ReminderDelivery) Body() string {
+	if d.Task.DueDate == nil {
+		return fmt.Sprintf("This is a reminder about your task \"%s\".", d.Task.Title)
+	}
+	return fmt.Sprintf("Your task \"%s\" is due %s.", d.Task.Title, d.Task.DueDate.UTC().Format(time.RFC1123))
+}
+
+type emailReminderNotifier struct {
+	mail mailer.Mailer
+}
+
+func NewEmailReminderNotifier(mail mailer.Mailer) ReminderNotifier {
+	return &emailReminderNotifier{
+		mail: mail,
+	}
+}
+
+func (n *emailReminderNotifier) Notify(ctx context.Context, delivery ReminderDelivery) error {
+	return n.mail.Send(mailer.Message{
+		To:      delivery.User.Email,
+		Subject: delivery.Subject(),
+		Body:    delivery.Body(),
+	})
+}
+
+type webhookReminderNotifier struct {
+	client *http.Client
+	secret string
+}
+
+type webhookReminderPayload struct {
+	Event      string         `json:"event"`
+	ReminderID uint           `json:"reminder_id"`
+	RemindAt   time.Time      `json:"remind_at"`
+	Task       TaskSummaryDTO `json:"task"`
+	DueDate    *time.Time     `json:"due_date,omitempty"`
+	Message    string         `json:"message"`
+}
+
+func NewWebhookReminderNotifier(client *http.Client, secret string) ReminderNotifier {
+	return &webhookReminderNotifier{
+		client: client,
+		secret: secret,
+	}
+}
+
+func (n *webhookReminderNotifier) Notify(ctx context.Context, delivery ReminderDelivery) error {
+	payload, err := json.Marshal(webhookReminderPayload{
+		Event:      "task.reminder",
+		ReminderID: delivery.Reminder.ID,
+		RemindAt:   delivery.Reminder.RemindAt,
+		Task:       TaskToSummaryDTO(delivery.Task),
+		DueDate:    delivery.Task.DueDate,
+		Message:    delivery.Body(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Reminder.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return ReminderDeliveryError{Message: "invalid webhook URL", Err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Task-API-Event", "task.reminder")
+	if n.secret != "" {
+		mac := hmac.New(sha256.New, []byte(n.secret))
+		mac.Write(payload)
+		req.Header.Set("X-Task-API-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrWebhookAddressNotAllowed) {
+			return ReminderDeliveryError{Message: "webhook URL does not point to a public address", Err: err}
+		}
+		return ReminderDeliveryError{Message: "webhook request failed", Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return ReminderDeliveryError{Message: fmt.Sprintf("webhook responded with status %d", resp.StatusCode)}
+	}
+	return nil
+}
+
+type inAppReminderNotifier struct {
+	notificationRepo repositories.NotificationRepository
+}
+
+func NewInAppReminderNotifier(notificationRepo repositories.NotificationRepository) ReminderNotifier {
+	return &inAppReminderNotifier{
+		notificationRepo: notificationRepo,
+	}
+}
+
+func (n *inAppReminderNotifier) Notify(ctx context.Context, delivery ReminderDelivery) error {
+	taskID := delivery.Task.ID
+	reminderID := delivery.Reminder.ID
+	return n.notificationRepo.Create(&models.Notification{
+		UserID:     delivery.User.ID,
+		TaskID:     &taskID,
+		ReminderID: &reminderID,
+		Title:      delivery.Subject(),
+		Body:       delivery.Body(),
+	})
+}
\ No newline at end of file
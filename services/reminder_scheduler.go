@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"task-api/models"
+	"task-api/repositories"
+
+	"gorm.io/gorm"
+)
+
+const (
+	reminderDeliveryTimeout = 10 * time.Second
+	maxReminderRetryDelay   = time.Hour
+
+	// minReminderLease leaves room for a delivery and its bookkeeping after
+	// the lease is renewed.
+	minReminderLease = 3 * reminderDeliveryTimeout
+)
+
+type ReminderSchedulerConfig struct {
+	Enabled       bool
+	PollInterval  time.Duration
+	LeaseDuration time.Duration
+	BatchSize     int
+	MaxAttempts   int
+	RetryDelay    time.Duration
+}
+
+func ReminderSchedulerConfigFromEnv() ReminderSchedulerConfig {
+	enabled := true
+	if value, err := strconv.ParseBool(os.Getenv("REMINDER_SCHEDULER_ENABLED")); err == nil {
+		enabled = value
+	}
+
+	lease := time.Duration(getEnvInt("REMINDER_LEASE_SECONDS", 120)) * time.Second
+	if lease < minReminderLease {
+		lease = minReminderLease
+	}
+
+	return ReminderSchedulerConfig{
+		Enabled:       enabled,
+		PollInterval:  time.Duration(getEnvInt("REMINDER_POLL_INTERVAL_SECONDS", 15)) * time.Second,
+		LeaseDuration: lease,
+		BatchSize:     getEnvInt("REMINDER_BATCH_SIZE", 50),
+		MaxAttempts:   getEnvInt("REMINDER_MAX_ATTEMPTS", 5),
+		RetryDelay:    time.Duration(getEnvInt("REMINDER_RETRY_DELAY_SECONDS", 60)) * time.Second,
+	}
+}
+
+type ReminderScheduler struct {
+	reminderRepo repositories.ReminderRepository
+	taskRepo     repositories.TaskRepository
+	userRepo     repositories.UserRepository
+	permissions  TaskPermissions
+	notifiers    map[models.ReminderChannel]ReminderNotifier
+	config       ReminderSchedulerConfig
+	owner        string
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewReminderScheduler(reminderRepo repositories.ReminderRepository, taskRepo repositories.TaskRepository, userRepo repositories.UserRepository, permissions TaskPermissions, notifiers map[models.ReminderChannel]ReminderNotifier, config ReminderSchedulerConfig) *ReminderScheduler {
+	hostname, _ := os.Hostname()
+	owner := fmt.Sprintf("%s-%d-%x", hostname, os.Getpid(), time.Now().UnixNano())
+	if len(owner) > 64 {
+		owner = owner[len(owner)-64:]
+	}
+
+	return &ReminderScheduler{
+		reminderRepo: reminderRepo,
+		taskRepo:     taskRepo,
+		userRepo:     userRepo,
+		permissions:  permissions,
+		notifiers:    notifiers,
+		config:       config,
+		owner:        owner,
+	}
+}
+
+func (s *ReminderScheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go s.run(ctx)
+	log.Printf("Reminder scheduler started as %s", s.owner)
+}
+
+func (s *ReminderScheduler) Shutdown(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return s.reminderRepo.ReleaseLeases(s.owner)
+}
+
+func (s *ReminderScheduler) run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ReminderScheduler) poll(ctx context.Context) {
+	reminders, err := s.reminderRepo.ClaimDue(s.owner, time.Now(), s.config.LeaseDuration, s.config.BatchSize)
+	if err != nil {
+		log.Printf("Failed to claim due reminders: %v", err)
+		return
+	}
+
+	for i := range reminders {
+		if ctx.Err() != nil {
+			return
+		}
+		s.deliver(&reminders[i])
+	}
+}
+
+func (s *ReminderScheduler) deliver(reminder *models.Reminder) {
+	task, err := s.taskRepo.GetByID(reminder.TaskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.cancelReminder(reminder)
+			return
+		}
+		s.fail(reminder, err)
+		return
+	}
+
+	if task.IsClosed() {
+		s.cancelReminder(reminder)
+		return
+	}
+
+	if err := s.permissions.Authorize(reminder.UserID, task, TaskActionView); err != nil {
+		if errors.Is(err, ErrUnauthorizedAccess) {
+			s.cancelReminder(reminder)
+			return
+		}
+		s.fail(reminder, err)
+		return
+	}
+
+	notifier, ok := s.notifiers[reminder.Channel]
+	if !ok {
+		s.fail(reminder, fmt.Errorf("no notifier configured for channel %s", reminder.Channel))
+		return
+	}
+
+	user, err := s.userRepo.GetByID(reminder.UserID)
+	if err != nil {
+		s.fail(reminder, err)
+		return
+	}
+
+	// The batch may have taken longer than the lease it was claimed with, so
+	// the lease is checked and extended right before sending.
+	if err := s.reminderRepo.RenewLease(reminder.ID, s.owner, time.Now().Add(s.config.LeaseDuration)); err != nil {
+		s.logLeaseError(reminder, err, "Failed to renew the lease of reminder %d")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), reminderDeliveryTimeout)
+	defer cancel()
+
+	if err := notifier.Notify(ctx, ReminderDelivery{Reminder: reminder, Task: task, User: user}); err != nil {
+		s.fail(reminder, err)
+		return
+	}
+
+	if err := s.reminderRepo.MarkSent(reminder.ID, s.owner, time.Now()); err != nil {
+		s.logLeaseError(reminder, err, "Failed to mark reminder %d as sent")
+	}
+}
+
+func (s *ReminderScheduler) cancelReminder(reminder *models.Reminder) {
+	if err := s.reminderRepo.Cancel(reminder.ID, s.owner); err != nil {
+		s.logLeaseError(reminder, err, "Failed to cancel reminder %d")
+	}
+}
+
+func (s *ReminderScheduler) fail(reminder *models.Reminder, deliveryErr error) {
+	log.Printf("Reminder %d delivery failed: %v", reminder.ID, deliveryErr)
+
+	var retryAt *time.Time
+	if attempts := reminder.Attempts + 1; attempts < s.config.MaxAttempts {
+		delay := s.config.RetryDelay << (attempts - 1)
+		if delay <= 0 || delay > maxReminderRetryDelay {
+			delay = maxReminderRetryDelay
+		}
+		next := time.Now().Add(delay)
+		retryAt = &next
+	}
+
+	lastError := "reminder delivery failed"
+	var publicErr ReminderDeliveryError
+	if errors.As(deliveryErr, &publicErr) {
+		lastError = publicErr.Message
+	}
+
+	if err := s.reminderRepo.MarkFailed(reminder.ID, s.owner, lastError, retryAt); err != nil {
+		s.logLeaseError(reminder, err, "Failed to record reminder %d failure")
+	}
+}
+
+func (s *ReminderScheduler) logLeaseError(reminder *models.Reminder, err error, format string) {
+	if errors.Is(err, repositories.ErrReminderLeaseLost) {
+		log.Printf("Reminder %d is no longer leased by %s; leaving it to its new owner", reminder.ID, s.owner)
+		return
+	}
+	log.Printf(format+": %v", reminder.ID, err)
+}
\ No newline at end of file
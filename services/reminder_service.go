@@ -0,0 +1,8 @@
+package services
+
+type ReminderService interface {
+	InWorkspace(workspaceID uint) ReminderService
+	CreateReminder(userID, taskID uint, dto CreateReminderDTO) (*ReminderResponseDTO, error)
+	ListReminders(userID, taskID uint) ([]ReminderResponseDTO, error)
+	DeleteReminder(userID, taskID, reminderID uint) error
+}
\ No newline at end of file
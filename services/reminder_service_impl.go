@@ -0,0 +1,161 @@
+package services
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"task-api/models"
+	"task-api/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrReminderNotFound = errors.New("reminder not found")
+)
+
+const maxPendingRemindersPerTask = 10
+
+type reminderService struct {
+	reminderRepo repositories.ReminderRepository
+	permissions  TaskPermissions
+}
+
+func NewReminderService(reminderRepo repositories.ReminderRepository, permissions TaskPermissions) ReminderService {
+	return &reminderService{
+		reminderRepo: reminderRepo,
+		permissions:  permissions,
+	}
+}
+
+func (s *reminderService) InWorkspace(workspaceID uint) ReminderService {
+	scoped := *s
+	scoped.permissions = s.permissions.InWorkspace(workspaceID)
+	return &scoped
+}
+
+func (s *reminderService) CreateReminder(userID, taskID uint, dto CreateReminderDTO) (*ReminderResponseDTO, error) {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.StatusCategory == models.TaskStatusCategoryDone {
+		return nil, ErrTaskAlreadyCompleted
+	}
+
+	remindAt, err := s.validateCreateReminder(task, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := s.reminderRepo.CountPendingByTaskID(task.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending >= maxPendingRemindersPerTask {
+		return nil, NewValidationError("task", "a task can have at most 10 pending reminders")
+	}
+
+	reminder := &models.Reminder{
+		TaskID:        task.ID,
+		UserID:        userID,
+		Channel:       dto.Channel,
+		WebhookURL:    dto.WebhookURL,
+		OffsetMinutes: dto.OffsetMinutes,
+		RemindAt:      remindAt,
+		Status:        models.ReminderStatusPending,
+	}
+
+	if err := s.reminderRepo.Create(reminder); err != nil {
+		return nil, err
+	}
+
+	response := ReminderToResponseDTO(reminder)
+	return &response, nil
+}
+
+func (s *reminderService) ListReminders(userID, taskID uint) ([]ReminderResponseDTO, error) {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders, err := s.reminderRepo.ListByTaskID(task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	own := make([]models.Reminder, 0, len(reminders))
+	for _, reminder := range reminders {
+		if reminder.UserID == userID {
+			own = append(own, reminder)
+		}
+	}
+
+	return RemindersToResponseDTO(own), nil
+}
+
+func (s *reminderService) DeleteReminder(userID, taskID, reminderID uint) error {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionView)
+	if err != nil {
+		return err
+	}
+
+	reminder, err := s.reminderRepo.GetByID(reminderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrReminderNotFound
+		}
+		return err
+	}
+
+	if reminder.TaskID != task.ID || reminder.UserID != userID {
+		return ErrReminderNotFound
+	}
+
+	return s.reminderRepo.Delete(reminder.ID)
+}
+
+func (s *reminderService) validateCreateReminder(task *models.Task, dto CreateReminderDTO) (time.Time, error) {
+	var validationErrors ValidationErrors
+	var remindAt time.Time
+
+	switch {
+	case dto.OffsetMinutes != nil && dto.RemindAt != nil:
+		validationErrors.AddError("remind_at", "set either offset_minutes or remind_at, not both")
+	case dto.OffsetMinutes != nil:
+		if task.DueDate == nil {
+			validationErrors.AddError("offset_minutes", "task has no due date")
+		} else {
+			remindAt = task.DueDate.Add(-time.Duration(*dto.OffsetMinutes) * time.Minute)
+		}
+	case dto.RemindAt != nil:
+		remindAt = *dto.RemindAt
+	default:
+		validationErrors.AddError("remind_at", "set either offset_minutes or remind_at")
+	}
+
+	if !remindAt.IsZero() && remindAt.Before(time.Now()) {
+		validationErrors.AddError("remind_at", "reminder time has already passed")
+	}
+
+	if dto.Channel == models.ReminderChannelWebhook {
+		if parsed, err := url.Parse(dto.WebhookURL); dto.WebhookURL == "" || err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			validationErrors.AddError("webhook_url", "webhook reminders need an http or https URL")
+		} else if err := checkWebhookURL(dto.WebhookURL); errors.Is(err, ErrWebhookAddressNotAllowed) {
+			validationErrors.AddError("webhook_url", "webhook URL must point to a public address")
+		} else if err != nil {
+			validationErrors.AddError("webhook_url", "webhook host could not be resolved")
+		}
+	} else if dto.WebhookURL != "" {
+		validationErrors.AddError("webhook_url", "only webhook reminders take a URL")
+	}
+
+	if validationErrors.HasErrors() {
+		return time.Time{}, validationErrors
+	}
+
+	return remindAt.UTC(), nil
+}
\ No newline at end of file
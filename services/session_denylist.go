@@ -0,0 +1,100 @@
+package services
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"task-api/repositories"
+)
+
+const (
+	DefaultDenylistRefreshInterval = 5 * time.Second
+)
+
+type denylistSnapshot struct {
+	revoked  map[string]struct{}
+	loadedAt time.Time
+}
+
+// SessionDenylist caches the families revoked within the access token
+// lifetime. Reads use the current snapshot without locking. Once it is older
+// than the refresh interval a single background refresh replaces it; only a
+// missing snapshot, or one stale for twice the interval because refreshes keep
+// failing, makes requests wait for the database.
+type SessionDenylist struct {
+	sessionRepo     repositories.SessionRepository
+	window          time.Duration
+	refreshInterval time.Duration
+
+	snapshot  atomic.Pointer[denylistSnapshot]
+	refreshMu sync.Mutex
+}
+
+func NewSessionDenylist(sessionRepo repositories.SessionRepository, window, refreshInterval time.Duration) *SessionDenylist {
+	return &SessionDenylist{
+		sessionRepo:     sessionRepo,
+		window:          window,
+		refreshInterval: refreshInterval,
+	}
+}
+
+func (d *SessionDenylist) IsSessionRevoked(sessionID string) (bool, error) {
+	snapshot := d.snapshot.Load()
+
+	switch {
+	case snapshot == nil || time.Since(snapshot.loadedAt) > 2*d.refreshInterval:
+		var err error
+		snapshot, err = d.refreshNow()
+		if err != nil {
+			return false, err
+		}
+	case time.Since(snapshot.loadedAt) > d.refreshInterval:
+		d.refreshInBackground()
+	}
+
+	_, found := snapshot.revoked[sessionID]
+	return found, nil
+}
+
+func (d *SessionDenylist) refreshNow() (*denylistSnapshot, error) {
+	d.refreshMu.Lock()
+	defer d.refreshMu.Unlock()
+
+	if snapshot := d.snapshot.Load(); snapshot != nil && time.Since(snapshot.loadedAt) <= d.refreshInterval {
+		return snapshot, nil
+	}
+	return d.refresh()
+}
+
+func (d *SessionDenylist) refreshInBackground() {
+	if !d.refreshMu.TryLock() {
+		return
+	}
+
+	go func() {
+		defer d.refreshMu.Unlock()
+		if _, err := d.refresh(); err != nil {
+			log.Printf("Failed to refresh session denylist: %v", err)
+		}
+	}()
+}
+
+func (d *SessionDenylist) refresh() (*denylistSnapshot, error) {
+	familyIDs, err := d.sessionRepo.ListRevokedFamilyIDsSince(time.Now().Add(-d.window))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &denylistSnapshot{
+		revoked:  make(map[string]struct{}, len(familyIDs)),
+		loadedAt: time.Now(),
+	}
+	for _, familyID := range familyIDs {
+		snapshot.revoked[familyID] = struct{}{}
+	}
+
+	d.snapshot.Store(snapshot)
+	return snapshot, nil
+}
\ No newline at end of file
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"task-api/models"
+)
+
+type trackedTaskField struct {
+	name  string
+	value func(task *models.Task) any
+}
+
+var trackedTaskFields = []trackedTaskField{
+	{"title", func(task *models.Task) any { return task.Title }},
+	{"description", func(task *models.Task) any { return task.Description }},
+	{"status", func(task *models.Task) any { return string(task.Status) }},
+	{"priority", func(task *models.Task) any { return string(task.Priority) }},
+	{"due_date", func(task *models.Task) any { return activityTime(task.DueDate) }},
+	{"completed_at", func(task *models.Task) any { return activityTime(task.CompletedAt) }},
+	{"assignee_id", func(task *models.Task) any { return activityID(task.AssigneeID) }},
+	{"parent_id", func(task *models.Task) any { return activityID(task.ParentID) }},
+	{"project_id", func(task *models.Task) any { return activityID(task.ProjectID) }},
+	{"labels", func(task *models.Task) any { return activityLabelIDs(task.Labels) }},
+	{"recurrence_rule", func(task *models.Task) any { return task.RecurrenceRule }},
+	{"recurrence_timezone", func(task *models.Task) any { return task.RecurrenceTimezone }},
+}
+
+// taskSnapshot holds the values of trackedTaskFields at one point in time, so
+// that later changes to the task cannot leak into it.
+type taskSnapshot []any
+
+func snapshotTask(task *models.Task) taskSnapshot {
+	snapshot := make(taskSnapshot, len(trackedTaskFields))
+	for i, field := range trackedTaskFields {
+		snapshot[i] = field.value(task)
+	}
+	return snapshot
+}
+
+func diffTaskSnapshots(before, after taskSnapshot) []models.TaskFieldChange {
+	changes := []models.TaskFieldChange{}
+	for i, field := range trackedTaskFields {
+		if !reflect.DeepEqual(before[i], after[i]) {
+			changes = append(changes, models.TaskFieldChange{
+				Field:  field.name,
+				Before: before[i],
+				After:  after[i],
+			})
+		}
+	}
+	return changes
+}
+
+func newTaskActivity(actorID, taskID uint, action models.TaskActivityAction, changes []models.TaskFieldChange) (models.TaskActivity, error) {
+	if changes == nil {
+		changes = []models.TaskFieldChange{}
+	}
+
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return models.TaskActivity{}, err
+	}
+
+	return models.TaskActivity{
+		TaskID:  taskID,
+		ActorID: actorID,
+		Action:  action,
+		Changes: string(encoded),
+	}, nil
+}
+
+func activityTime(value *time.Time) any {
+	if value == nil {
+		return nil
+	}
+	return value.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano)
+}
+
+func activityID(value *uint) any {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+func activityLabelIDs(labels []models.Label) []uint {
+	ids := make([]uint, len(labels))
+	for i, label := range labels {
+		ids[i] = label.ID
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
\ No newline at end of file
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"encoding/json"
+	"time"
+
+	"task-api/models"
+	"task-api/repositories"
+)
+
+type TaskActivityActorDTO struct {
+	ID        uint   `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+type TaskActivityResponseDTO struct {
+	ID        uint                      `json:"id"`
+	TaskID    uint                      `json:"task_id"`
+	Actor     TaskActivityActorDTO      `json:"actor"`
+	Action    models.TaskActivityAction `json:"action"`
+	Changes   []models.TaskFieldChange  `json:"changes"`
+	CreatedAt time.Time                 `json:"created_at"`
+}
+
+type TaskHistoryResponseDTO struct {
+	Activities []TaskActivityResponseDTO     `json:"activities"`
+	Pagination repositories.PaginationResult `json:"pagination"`
+}
+
+func TaskActivityToResponseDTO(activity *models.TaskActivity) TaskActivityResponseDTO {
+	changes := []models.TaskFieldChange{}
+	if err := json.Unmarshal([]byte(activity.Changes), &changes); err != nil || changes == nil {
+		changes = []models.TaskFieldChange{}
+	}
+
+	return TaskActivityResponseDTO{
+		ID:     activity.ID,
+		TaskID: activity.TaskID,
+		Actor: TaskActivityActorDTO{
+			ID:        activity.ActorID,
+			FirstName: activity.Actor.FirstName,
+			LastName:  activity.Actor.LastName,
+		},
+		Action:    activity.Action,
+		Changes:   changes,
+		CreatedAt: activity.CreatedAt,
+	}
+}
+
+func TaskActivitiesToResponseDTO(activities []models.TaskActivity) []TaskActivityResponseDTO {
+	result := make([]TaskActivityResponseDTO, len(activities))
+	for i, activity := range activities {
+		result[i] = TaskActivityToResponseDTO(&activity)
+	}
+	return result
+}
\ No newline at end of file
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"errors"
+
+	"task-api/models"
+	"task-api/repositories"
+
+	"gorm.io/gorm"
+)
+
+type TaskAction string
+
+const (
+	TaskActionView   TaskAction = "view"
+	TaskActionEdit   TaskAction = "edit"
+	TaskActionManage TaskAction = "manage"
+)
+
+type TaskRole string
+
+const (
+	TaskRoleNone     TaskRole = ""
+	TaskRoleOwner    TaskRole = "owner"
+	TaskRoleEditor   TaskRole = "editor"
+	TaskRoleAssignee TaskRole = "assignee"
+	TaskRoleViewer   TaskRole = "viewer"
+)
+
+var taskRoleActions = map[TaskRole][]TaskAction{
+	TaskRoleOwner:    {TaskActionView, TaskActionEdit, TaskActionManage},
+	TaskRoleEditor:   {TaskActionView, TaskActionEdit},
+	TaskRoleAssignee: {TaskActionView, TaskActionEdit},
+	TaskRoleViewer:   {TaskActionView},
+}
+
+func (r TaskRole) Can(action TaskAction) bool {
+	for _, allowed := range taskRoleActions[r] {
+		if allowed == action {
+			return true
+		}
+	}
+	return false
+}
+
+type TaskPermissions interface {
+	InWorkspace(workspaceID uint) TaskPermissions
+	Role(userID uint, task *models.Task) (TaskRole, error)
+	Authorize(userID uint, task *models.Task, action TaskAction) error
+	GetTask(userID, taskID uint, action TaskAction) (*models.Task, error)
+}
+
+type taskPermissions struct {
+	taskRepo  repositories.TaskRepository
+	shareRepo repositories.TaskShareRepository
+}
+
+func NewTaskPermissions(taskRepo repositories.TaskRepository, shareRepo repositories.TaskShareRepository) TaskPermissions {
+	return &taskPermissions{
+		taskRepo:  taskRepo,
+		shareRepo: shareRepo,
+	}
+}
+
+func (p *taskPermissions) InWorkspace(workspaceID uint) TaskPermissions {
+	return &taskPermissions{
+		taskRepo:  p.taskRepo.InWorkspace(workspaceID),
+		shareRepo: p.shareRepo,
+	}
+}
+
+func (p *taskPermissions) Role(userID uint, task *models.Task) (TaskRole, error) {
+	if task.UserID == userID {
+		return TaskRoleOwner, nil
+	}
+
+	share, err := p.shareRepo.Get(task.ID, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return TaskRoleNone, err
+	}
+
+	switch {
+	case share != nil && share.Permission == models.TaskSharePermissionEditor:
+		return TaskRoleEditor, nil
+	case task.AssigneeID != nil && *task.AssigneeID == userID:
+		return TaskRoleAssignee, nil
+	case share != nil:
+		return TaskRoleViewer, nil
+	default:
+		return TaskRoleNone, nil
+	}
+}
+
+func (p *taskPermissions) Authorize(userID uint, task *models.Task, action TaskAction) error {
+	role, err := p.Role(userID, task)
+	if err != nil {
+		return err
+	}
+
+	if !role.Can(action) {
+		return ErrUnauthorizedAccess
+	}
+
+	return nil
+}
+
+func (p *taskPermissions) GetTask(userID, taskID uint, action TaskAction) (*models.Task, error) {
+	task, err := p.taskRepo.GetByID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	if err := p.Authorize(userID, task, action); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
\ No newline at end of file
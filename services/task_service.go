@@ -5,11 +5,23 @@ import (
 )
 
 type TaskService interface {
+	InWorkspace(workspaceID uint) TaskService
 	CreateTask(userID uint, dto CreateTaskDTO) (*TaskResponseDTO, error)
 	GetTaskByID(userID, taskID uint) (*TaskResponseDTO, error)
-	GetUserTasks(userID uint, pagination repositories.PaginationParams) (*TaskListResponseDTO, error)
+	GetUserTasks(userID uint, query TaskListQueryDTO, pagination repositories.PaginationParams) (*TaskListResponseDTO, error)
+	GetProjectTasks(userID, projectID uint, query TaskListQueryDTO, pagination repositories.PaginationParams) (*TaskListResponseDTO, error)
 	UpdateTask(userID, taskID uint, dto UpdateTaskDTO) (*TaskResponseDTO, error)
 	DeleteTask(userID, taskID uint) error
-	GetAllTasks(pagination repositories.PaginationParams) (*TaskListResponseDTO, error)
-	CompleteTask(userID, taskID uint) (*TaskResponseDTO, error)
+	GetAllTasks(query TaskListQueryDTO, pagination repositories.PaginationParams) (*TaskListResponseDTO, error)
+	CompleteTask(userID, taskID uint, options CompleteTaskOptions) (*TaskResponseDTO, error)
+	GetSubtasks(userID, taskID uint) ([]TaskResponseDTO, error)
+	GetTaskHistory(userID, taskID uint, pagination repositories.PaginationParams) (*TaskHistoryResponseDTO, error)
+	ListDependencies(userID, taskID uint) (*TaskDependenciesDTO, error)
+	AddDependency(userID, taskID uint, dto AddTaskDependencyDTO) (*TaskDependenciesDTO, error)
+	RemoveDependency(userID, taskID, blockerID uint) error
+	SkipOccurrence(userID, taskID uint) (*TaskResponseDTO, error)
+	EndRecurrence(userID, taskID uint) (*TaskResponseDTO, error)
+	ListShares(userID, taskID uint) ([]TaskShareResponseDTO, error)
+	ShareTask(userID, taskID uint, dto ShareTaskDTO) (*TaskShareResponseDTO, error)
+	UnshareTask(userID, taskID, shareUserID uint) error
 }
\ No newline at end of file
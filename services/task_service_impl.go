@@ -2,151 +2,1128 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"task-api/models"
 	"task-api/repositories"
+	"task-api/utils"
 
 	"gorm.io/gorm"
 )
 
+var (
+	ErrOpenSubtasks       = errors.New("task has open subtasks")
+	ErrDependencyExists   = errors.New("dependency already exists")
+	ErrDependencyNotFound = errors.New("dependency not found")
+	ErrDependencyCycle    = errors.New("dependency would create a cycle")
+	ErrTaskNotRecurring   = errors.New("task is not recurring")
+	ErrRecurrenceFinished = errors.New("recurrence has no further occurrences")
+	ErrShareNotFound      = errors.New("task is not shared with this user")
+)
+
+type TaskBlockedError struct {
+	BlockerIDs []uint
+}
+
+func (e TaskBlockedError) Error() string {
+	return fmt.Sprintf("task is blocked by %d open task(s)", len(e.BlockerIDs))
+}
+
+type CompleteTaskOptions struct {
+	Subtasks       SubtaskCompletionMode
+	IgnoreBlockers bool
+}
+
+type SubtaskCompletionMode string
+
+const (
+	SubtaskCompletionRefuse  SubtaskCompletionMode = "refuse"
+	SubtaskCompletionCascade SubtaskCompletionMode = "cascade"
+)
+
+type TaskView string
+
+const (
+	TaskViewOwned    TaskView = "owned"
+	TaskViewAssigned TaskView = "assigned"
+	TaskViewShared   TaskView = "shared"
+)
+
+const (
+	maxSearchQueryLength      = 200
+	defaultRecurrenceTimezone = "UTC"
+)
+
 type taskService struct {
-	taskRepo repositories.TaskRepository
+	taskRepo           repositories.TaskRepository
+	userRepo           repositories.UserRepository
+	labelRepo          repositories.LabelRepository
+	projectRepo        repositories.ProjectRepository
+	dependencyRepo     repositories.TaskDependencyRepository
+	commentRepo        repositories.CommentRepository
+	shareRepo          repositories.TaskShareRepository
+	workspaceRepo      repositories.WorkspaceRepository
+	workflowRepo       repositories.WorkflowRepository
+	activityRepo       repositories.TaskActivityRepository
+	stateMachine       *TaskStateMachine
+	permissions        TaskPermissions
+	verificationPolicy EmailVerificationPolicy
+	workspaceID        uint
+}
+
+func NewTaskService(taskRepo repositories.TaskRepository, userRepo repositories.UserRepository, labelRepo repositories.LabelRepository, projectRepo repositories.ProjectRepository, dependencyRepo repositories.TaskDependencyRepository, commentRepo repositories.CommentRepository, shareRepo repositories.TaskShareRepository, workspaceRepo repositories.WorkspaceRepository, workflowRepo repositories.WorkflowRepository, activityRepo repositories.TaskActivityRepository, stateMachine *TaskStateMachine, permissions TaskPermissions, verificationPolicy EmailVerificationPolicy) TaskService {
+	return &taskService{
+		taskRepo:           taskRepo,
+		userRepo:           userRepo,
+		labelRepo:          labelRepo,
+		projectRepo:        projectRepo,
+		dependencyRepo:     dependencyRepo,
+		commentRepo:        commentRepo,
+		shareRepo:          shareRepo,
+		workspaceRepo:      workspaceRepo,
+		workflowRepo:       workflowRepo,
+		activityRepo:       activityRepo,
+		stateMachine:       stateMachine,
+		permissions:        permissions,
+		verificationPolicy: verificationPolicy,
+	}
+}
+
+func (s *taskService) InWorkspace(workspaceID uint) TaskService {
+	scoped := *s
+	scoped.workspaceID = workspaceID
+	scoped.taskRepo = s.taskRepo.InWorkspace(workspaceID)
+	scoped.projectRepo = s.projectRepo.InWorkspace(workspaceID)
+	scoped.dependencyRepo = s.dependencyRepo.InWorkspace(workspaceID)
+	scoped.workflowRepo = s.workflowRepo.InWorkspace(workspaceID)
+	scoped.permissions = s.permissions.InWorkspace(workspaceID)
+	return &scoped
+}
+
+func (s *taskService) CreateTask(userID uint, dto CreateTaskDTO) (*TaskResponseDTO, error) {
+	if err := s.validateCreateTask(dto); err != nil {
+		return nil, err
+	}
+
+	if s.verificationPolicy.BlocksTaskCreation() {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		if !user.IsEmailVerified() {
+			return nil, ErrEmailNotVerified
+		}
+	}
+
+	if dto.ParentID != nil && *dto.ParentID != 0 {
+		parent, err := s.getParentTask(userID, *dto.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if dto.ProjectID == nil {
+			dto.ProjectID = parent.ProjectID
+		}
+	} else {
+		dto.ParentID = nil
+	}
+
+	if dto.ProjectID != nil && *dto.ProjectID != 0 {
+		if err := s.checkTaskProject(userID, *dto.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	if dto.AssigneeID != nil && *dto.AssigneeID != 0 {
+		if err := s.checkAssignee(*dto.AssigneeID); err != nil {
+			return nil, err
+		}
+	}
+
+	if strings.TrimSpace(dto.RecurrenceRule) != "" {
+		rule, timezone, err := normalizeRecurrence(dto.RecurrenceRule, dto.RecurrenceTimezone, dto.DueDate)
+		if err != nil {
+			return nil, err
+		}
+		dto.RecurrenceRule, dto.RecurrenceTimezone = rule, timezone
+	} else {
+		dto.RecurrenceRule = ""
+	}
+
+	labels, err := s.resolveLabels(userID, dto.LabelIDs, dto.Labels, true)
+	if err != nil {
+		return nil, err
+	}
+
+	task := dto.ToModel(userID)
+	task.Labels = labels
+
+	workflow, err := loadTaskWorkflow(s.workflowRepo, s.workspaceID, task.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	initial := workflow.Initial()
+	task.Status, task.StatusCategory = initial.Key, initial.Category
+
+	err = s.taskRepo.Transaction(func(tx repositories.TaskTx) error {
+		if err := tx.Tasks.Create(task); err != nil {
+			return err
+		}
+
+		if task.IsRecurring() {
+			seriesID := task.ID
+			task.RecurrenceSeriesID = &seriesID
+			if err := tx.Tasks.Update(task); err != nil {
+				return err
+			}
+		}
+
+		return s.recordActivity(tx.Activities, userID, task, models.TaskActivityCreated, snapshotTask(&models.Task{}))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.taskResponse(task)
 }
 
-func NewTaskService(taskRepo repositories.TaskRepository) TaskService {
-	return &taskService{
-		taskRepo: taskRepo,
+func (s *taskService) GetTaskByID(userID, taskID uint) (*TaskResponseDTO, error) {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.taskResponse(task)
+}
+
+func (s *taskService) GetUserTasks(userID uint, query TaskListQueryDTO, pagination repositories.PaginationParams) (*TaskListResponseDTO, error) {
+	view := TaskView(query.View)
+	switch view {
+	case "":
+		view = TaskViewOwned
+	case TaskViewOwned, TaskViewAssigned, TaskViewShared:
+	default:
+		return nil, NewValidationError("view", "must be owned, assigned or shared")
+	}
+
+	labelOwnerID := userID
+	if view != TaskViewOwned {
+		labelOwnerID = 0
+	}
+
+	filter, err := s.buildTaskFilter(labelOwnerID, query, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []models.Task
+	var paginationResult repositories.PaginationResult
+	switch view {
+	case TaskViewAssigned:
+		filter.AssigneeID = &userID
+		tasks, paginationResult, err = s.taskRepo.List(filter, pagination)
+	case TaskViewShared:
+		filter.SharedWithID = &userID
+		tasks, paginationResult, err = s.taskRepo.List(filter, pagination)
+	default:
+		tasks, paginationResult, err = s.taskRepo.GetByUserID(userID, filter, pagination)
+	}
+	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidCursor) {
+			return nil, invalidCursorError(pagination)
+		}
+		return nil, err
+	}
+
+	return s.taskListResponse(tasks, filter, paginationResult)
+}
+
+func (s *taskService) GetProjectTasks(userID, projectID uint, query TaskListQueryDTO, pagination repositories.PaginationParams) (*TaskListResponseDTO, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	if project.UserID != userID {
+		return nil, ErrProjectNotFound
+	}
+
+	query.ProjectID = ""
+	filter, err := s.buildTaskFilter(userID, query, pagination)
+	if err != nil {
+		return nil, err
+	}
+	filter.ProjectID = &project.ID
+
+	tasks, paginationResult, err := s.taskRepo.GetByUserID(userID, filter, pagination)
+	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidCursor) {
+			return nil, invalidCursorError(pagination)
+		}
+		return nil, err
+	}
+
+	return s.taskListResponse(tasks, filter, paginationResult)
+}
+
+func (s *taskService) UpdateTask(userID, taskID uint, dto UpdateTaskDTO) (*TaskResponseDTO, error) {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionEdit)
+	if err != nil {
+		return nil, err
+	}
+	before := snapshotTask(task)
+
+	if err := s.validateUpdateTask(dto); err != nil {
+		return nil, err
+	}
+
+	if dto.ParentID != nil && *dto.ParentID != 0 && (task.ParentID == nil || *task.ParentID != *dto.ParentID) {
+		if err := s.checkNewParent(task, *dto.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	if dto.ProjectID != nil && *dto.ProjectID != 0 && (task.ProjectID == nil || *task.ProjectID != *dto.ProjectID) {
+		if err := s.checkTaskProject(task.UserID, *dto.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	if dto.AssigneeID != nil && *dto.AssigneeID != 0 && (task.AssigneeID == nil || *task.AssigneeID != *dto.AssigneeID) {
+		if err := s.checkAssignee(*dto.AssigneeID); err != nil {
+			return nil, err
+		}
+	}
+
+	projectID := task.ProjectID
+	if dto.ProjectID != nil {
+		projectID = nil
+		if *dto.ProjectID != 0 {
+			projectID = dto.ProjectID
+		}
+	}
+
+	workflow, err := loadTaskWorkflow(s.workflowRepo, task.WorkspaceID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := workflow.Current(task)
+	target := current
+	if dto.Status != nil {
+		target, err = workflow.Check(current, *dto.Status)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var warnings []string
+	if target.Key != current.Key && (target.Category == models.TaskStatusCategoryInProgress || target.Category == models.TaskStatusCategoryDone) {
+		warnings, err = s.checkBlockers(task, dto.IgnoreBlockers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	completing := target.Category == models.TaskStatusCategoryDone && current.Category != models.TaskStatusCategoryDone
+	if completing {
+		openSubtasks, err := s.taskRepo.CountOpenDescendants(task.ID)
+		if err != nil {
+			return nil, err
+		}
+		if openSubtasks > 0 {
+			return nil, ErrOpenSubtasks
+		}
+	}
+
+	if dto.RecurrenceRule != nil || dto.RecurrenceTimezone != nil {
+		if err := s.applyRecurrenceUpdate(task, dto); err != nil {
+			return nil, err
+		}
+	}
+
+	var labels []models.Label
+	if dto.LabelIDs != nil || dto.Labels != nil {
+		var labelIDs []uint
+		var labelNames []string
+		if dto.LabelIDs != nil {
+			labelIDs = *dto.LabelIDs
+		}
+		if dto.Labels != nil {
+			labelNames = *dto.Labels
+		}
+
+		// Labels belong to the task owner, so only the owner may create new
+		// ones by name.
+		labels, err = s.resolveLabels(task.UserID, labelIDs, labelNames, userID == task.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dueDateChanged := dto.DueDate != nil && (task.DueDate == nil || !task.DueDate.Equal(*dto.DueDate))
+
+	s.applyUpdates(task, dto)
+
+	task.Status = current.Key
+	if err := s.stateMachine.Transition(task, current, target); err != nil {
+		return nil, err
+	}
+
+	action := models.TaskActivityUpdated
+	if completing {
+		action = models.TaskActivityCompleted
+	}
+
+	var next *models.Task
+	err = s.taskRepo.Transaction(func(tx repositories.TaskTx) error {
+		if err := tx.Tasks.Update(task); err != nil {
+			return err
+		}
+
+		if dto.LabelIDs != nil || dto.Labels != nil {
+			if err := tx.Tasks.ReplaceLabels(task, labels); err != nil {
+				return err
+			}
+		}
+
+		if err := s.recordActivity(tx.Activities, userID, task, action, before); err != nil {
+			return err
+		}
+
+		if dueDateChanged {
+			if err := tx.Reminders.RescheduleRelative(task.ID, *task.DueDate); err != nil {
+				return err
+			}
+		}
+
+		if completing {
+			next, err = s.spawnNextOccurrence(tx, userID, task)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.completionResponse(task, next, warnings)
+}
+
+func (s *taskService) DeleteTask(userID, taskID uint) error {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionManage)
+	if err != nil {
+		return err
+	}
+
+	descendantIDs, err := s.taskRepo.ListDescendantIDs(task.ID)
+	if err != nil {
+		return err
+	}
+
+	deleted := make([]models.TaskActivity, 0, len(descendantIDs)+1)
+	for _, id := range append([]uint{task.ID}, descendantIDs...) {
+		activity, err := newTaskActivity(userID, id, models.TaskActivityDeleted, nil)
+		if err != nil {
+			return err
+		}
+		deleted = append(deleted, activity)
+	}
+
+	return s.taskRepo.Transaction(func(tx repositories.TaskTx) error {
+		if err := tx.Tasks.Delete(task.ID); err != nil {
+			return err
+		}
+		return tx.Activities.CreateBatch(deleted)
+	})
+}
+
+func (s *taskService) GetAllTasks(query TaskListQueryDTO, pagination repositories.PaginationParams) (*TaskListResponseDTO, error) {
+	filter, err := s.buildTaskFilter(0, query, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, paginationResult, err := s.taskRepo.List(filter, pagination)
+	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidCursor) {
+			return nil, invalidCursorError(pagination)
+		}
+		return nil, err
+	}
+
+	return s.taskListResponse(tasks, filter, paginationResult)
+}
+
+func (s *taskService) CompleteTask(userID, taskID uint, options CompleteTaskOptions) (*TaskResponseDTO, error) {
+	mode := options.Subtasks
+	switch mode {
+	case "":
+		mode = SubtaskCompletionRefuse
+	case SubtaskCompletionRefuse, SubtaskCompletionCascade:
+	default:
+		return nil, NewValidationError("subtasks", "must be refuse or cascade")
+	}
+
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.taskWorkflow(task)
+	if err != nil {
+		return nil, err
+	}
+
+	current := workflow.Current(task)
+	if current.Category == models.TaskStatusCategoryDone {
+		return nil, ErrTaskAlreadyCompleted
+	}
+	before := snapshotTask(task)
+
+	target, ok := workflow.NextInCategory(current, models.TaskStatusCategoryDone)
+	if !ok {
+		done, _ := workflow.First(models.TaskStatusCategoryDone)
+		return nil, InvalidTransitionError{From: current.Key, To: done.Key, Allowed: workflow.Allowed(current)}
+	}
+
+	warnings, err := s.checkBlockers(task, options.IgnoreBlockers)
+	if err != nil {
+		return nil, err
+	}
+
+	openSubtasks, err := s.taskRepo.CountOpenDescendants(task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if openSubtasks > 0 && mode != SubtaskCompletionCascade {
+		return nil, ErrOpenSubtasks
+	}
+
+	if err := s.stateMachine.Transition(task, current, target); err != nil {
+		return nil, err
+	}
+
+	var next *models.Task
+	err = s.taskRepo.Transaction(func(tx repositories.TaskTx) error {
+		if openSubtasks > 0 {
+			if err := s.completeDescendants(tx, userID, task.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Tasks.Update(task); err != nil {
+			return err
+		}
+
+		if err := s.recordActivity(tx.Activities, userID, task, models.TaskActivityCompleted, before); err != nil {
+			return err
+		}
+
+		next, err = s.spawnNextOccurrence(tx, userID, task)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.completionResponse(task, next, warnings)
+}
+
+func (s *taskService) GetSubtasks(userID, taskID uint) ([]TaskResponseDTO, error) {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := s.taskRepo.ListChildren(task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.taskResponses(children)
+}
+
+func (s *taskService) GetTaskHistory(userID, taskID uint, pagination repositories.PaginationParams) (*TaskHistoryResponseDTO, error) {
+	if pagination.IsCursor() {
+		return nil, NewValidationError("pagination", "history supports page-based pagination only")
+	}
+
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	activities, paginationResult, err := s.activityRepo.ListByTaskID(task.ID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskHistoryResponseDTO{
+		Activities: TaskActivitiesToResponseDTO(activities),
+		Pagination: paginationResult,
+	}, nil
+}
+
+func (s *taskService) ListDependencies(userID, taskID uint) (*TaskDependenciesDTO, error) {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dependenciesResponse(task.ID)
+}
+
+func (s *taskService) AddDependency(userID, taskID uint, dto AddTaskDependencyDTO) (*TaskDependenciesDTO, error) {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.BlockedByID == task.ID {
+		return nil, NewValidationError("blocked_by_id", "a task cannot block itself")
+	}
+
+	blocker, err := s.taskRepo.GetByID(dto.BlockedByID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, NewValidationError("blocked_by_id", "blocking task does not exist")
+		}
+		return nil, err
+	}
+	if blocker.UserID != task.UserID {
+		return nil, NewValidationError("blocked_by_id", "blocking task does not exist")
+	}
+
+	if _, err := s.dependencyRepo.Get(blocker.ID, task.ID); err == nil {
+		return nil, ErrDependencyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	edges, err := s.dependencyRepo.ListByUserID(task.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if dependencyCreatesCycle(edges, blocker.ID, task.ID) {
+		return nil, ErrDependencyCycle
+	}
+
+	dependency := &models.TaskDependency{
+		BlockerID: blocker.ID,
+		BlockedID: task.ID,
+	}
+	if err := s.dependencyRepo.Create(dependency); err != nil {
+		return nil, err
+	}
+
+	return s.dependenciesResponse(task.ID)
+}
+
+func (s *taskService) RemoveDependency(userID, taskID, blockerID uint) error {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionEdit)
+	if err != nil {
+		return err
+	}
+
+	dependency, err := s.dependencyRepo.Get(blockerID, task.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrDependencyNotFound
+		}
+		return err
+	}
+
+	return s.dependencyRepo.Delete(dependency.ID)
+}
+
+func (s *taskService) SkipOccurrence(userID, taskID uint) (*TaskResponseDTO, error) {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if !task.IsRecurring() {
+		return nil, ErrTaskNotRecurring
+	}
+
+	if task.StatusCategory == models.TaskStatusCategoryDone {
+		return nil, ErrTaskAlreadyCompleted
+	}
+
+	dueDate, ok, err := nextOccurrenceDue(task)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrRecurrenceFinished
+	}
+
+	before := snapshotTask(task)
+	task.DueDate = &dueDate
+	task.RecurrenceIndex++
+
+	err = s.taskRepo.Transaction(func(tx repositories.TaskTx) error {
+		if err := tx.Tasks.Update(task); err != nil {
+			return err
+		}
+		if err := s.recordActivity(tx.Activities, userID, task, models.TaskActivityUpdated, before); err != nil {
+			return err
+		}
+		return tx.Reminders.RescheduleRelative(task.ID, dueDate)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.taskResponse(task)
+}
+
+func (s *taskService) EndRecurrence(userID, taskID uint) (*TaskResponseDTO, error) {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if !task.IsRecurring() {
+		return nil, ErrTaskNotRecurring
+	}
+
+	seriesID := task.ID
+	if task.RecurrenceSeriesID != nil {
+		seriesID = *task.RecurrenceSeriesID
+	}
+
+	err = s.taskRepo.Transaction(func(tx repositories.TaskTx) error {
+		series, err := tx.Tasks.ListRecurrenceSeries(seriesID)
+		if err != nil {
+			return err
+		}
+
+		ended := make([]models.TaskActivity, 0, len(series))
+		for i := range series {
+			before := snapshotTask(&series[i])
+			series[i].RecurrenceRule = ""
+			series[i].RecurrenceTimezone = ""
+
+			changes := diffTaskSnapshots(before, snapshotTask(&series[i]))
+			if len(changes) == 0 {
+				continue
+			}
+			activity, err := newTaskActivity(userID, series[i].ID, models.TaskActivityUpdated, changes)
+			if err != nil {
+				return err
+			}
+			ended = append(ended, activity)
+		}
+
+		if err := tx.Tasks.EndRecurrenceSeries(seriesID); err != nil {
+			return err
+		}
+		return tx.Activities.CreateBatch(ended)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	task.RecurrenceRule = ""
+	task.RecurrenceTimezone = ""
+
+	return s.taskResponse(task)
+}
+
+func (s *taskService) ListShares(userID, taskID uint) ([]TaskShareResponseDTO, error) {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := s.shareRepo.ListByTaskID(task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return TaskSharesToResponseDTO(shares), nil
+}
+
+func (s *taskService) ShareTask(userID, taskID uint, dto ShareTaskDTO) (*TaskShareResponseDTO, error) {
+	task, err := s.permissions.GetTask(userID, taskID, TaskActionManage)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.resolveShareTarget(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.ID == task.UserID {
+		return nil, NewValidationError("user_id", "the task owner already has full access")
+	}
+
+	if _, err := s.workspaceRepo.GetMember(task.WorkspaceID, target.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, NewValidationError("user_id", "user is not a member of this workspace")
+		}
+		return nil, err
+	}
+
+	share := &models.TaskShare{
+		TaskID:     task.ID,
+		UserID:     target.ID,
+		Permission: dto.Permission,
+	}
+	if err := s.shareRepo.Upsert(share); err != nil {
+		return nil, err
+	}
+
+	share, err = s.shareRepo.Get(task.ID, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	share.User = *target
+
+	response := TaskShareToResponseDTO(share)
+	return &response, nil
+}
+
+func (s *taskService) UnshareTask(userID, taskID, shareUserID uint) error {
+	action := TaskActionManage
+	if shareUserID == userID {
+		action = TaskActionView
+	}
+
+	task, err := s.permissions.GetTask(userID, taskID, action)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.shareRepo.Get(task.ID, shareUserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrShareNotFound
+		}
+		return err
+	}
+
+	return s.shareRepo.Delete(task.ID, shareUserID)
+}
+
+func (s *taskService) resolveShareTarget(dto ShareTaskDTO) (*models.User, error) {
+	email := strings.TrimSpace(dto.Email)
+	switch {
+	case dto.UserID == nil && email == "":
+		return nil, NewValidationError("user_id", "user_id or email is required")
+	case dto.UserID != nil && email != "":
+		return nil, NewValidationError("user_id", "give either user_id or email, not both")
+	}
+
+	var user *models.User
+	var err error
+	field := "user_id"
+	if dto.UserID != nil {
+		user, err = s.userRepo.GetByID(*dto.UserID)
+	} else {
+		field = "email"
+		user, err = s.userRepo.GetByEmail(email)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, NewValidationError(field, "user does not exist")
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *taskService) dependenciesResponse(taskID uint) (*TaskDependenciesDTO, error) {
+	blockers, err := s.dependencyRepo.ListBlockers(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocking, err := s.dependencyRepo.ListBlocking(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskDependenciesDTO{
+		BlockedBy: TasksToSummaryDTO(blockers),
+		Blocking:  TasksToSummaryDTO(blocking),
+	}, nil
+}
+
+func (s *taskService) applyRecurrenceUpdate(task *models.Task, dto UpdateTaskDTO) error {
+	rule := task.RecurrenceRule
+	if dto.RecurrenceRule != nil {
+		rule = strings.TrimSpace(*dto.RecurrenceRule)
+	}
+
+	if rule == "" {
+		task.RecurrenceRule = ""
+		task.RecurrenceTimezone = ""
+		return nil
+	}
+
+	timezone := task.RecurrenceTimezone
+	if dto.RecurrenceTimezone != nil {
+		timezone = *dto.RecurrenceTimezone
+	}
+
+	dueDate := task.DueDate
+	if dto.DueDate != nil {
+		dueDate = dto.DueDate
+	}
+
+	rule, timezone, err := normalizeRecurrence(rule, timezone, dueDate)
+	if err != nil {
+		return err
+	}
+
+	task.RecurrenceRule = rule
+	task.RecurrenceTimezone = timezone
+	if task.RecurrenceSeriesID == nil {
+		seriesID := task.ID
+		task.RecurrenceSeriesID = &seriesID
+		task.RecurrenceIndex = 0
+	}
+
+	return nil
+}
+
+func (s *taskService) spawnNextOccurrence(tx repositories.TaskTx, actorID uint, task *models.Task) (*models.Task, error) {
+	if !task.IsRecurring() || task.RecurrenceSeriesID == nil {
+		return nil, nil
+	}
+
+	dueDate, ok, err := nextOccurrenceDue(task)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	workflow, err := s.taskWorkflow(task)
+	if err != nil {
+		return nil, err
+	}
+	initial := workflow.Initial()
+
+	next := &models.Task{
+		Title:              task.Title,
+		Description:        task.Description,
+		Status:             initial.Key,
+		StatusCategory:     initial.Category,
+		Priority:           task.Priority,
+		DueDate:            &dueDate,
+		UserID:             task.UserID,
+		WorkspaceID:        task.WorkspaceID,
+		ParentID:           task.ParentID,
+		ProjectID:          task.ProjectID,
+		Labels:             task.Labels,
+		RecurrenceRule:     task.RecurrenceRule,
+		RecurrenceTimezone: task.RecurrenceTimezone,
+		RecurrenceSeriesID: task.RecurrenceSeriesID,
+		RecurrenceIndex:    task.RecurrenceIndex + 1,
+	}
+
+	if err := tx.Tasks.Create(next); err != nil {
+		return nil, err
 	}
-}
 
-func (s *taskService) CreateTask(userID uint, dto CreateTaskDTO) (*TaskResponseDTO, error) {
-	if err := s.validateCreateTask(dto); err != nil {
+	if err := s.recordActivity(tx.Activities, actorID, next, models.TaskActivityCreated, snapshotTask(&models.Task{})); err != nil {
 		return nil, err
 	}
 
-	task := dto.ToModel(userID)
-	
-	if err := s.taskRepo.Create(task); err != nil {
+	if err := copyRelativeReminders(tx.Reminders, task, next); err != nil {
 		return nil, err
 	}
 
-	response := TaskToResponseDTO(task)
-	return &response, nil
+	return next, nil
 }
 
-func (s *taskService) GetTaskByID(userID, taskID uint) (*TaskResponseDTO, error) {
-	task, err := s.taskRepo.GetByID(taskID)
+func copyRelativeReminders(reminderRepo repositories.ReminderRepository, from, to *models.Task) error {
+	reminders, err := reminderRepo.ListByTaskID(from.ID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, ErrTaskNotFound
-		}
-		return nil, err
+		return err
 	}
 
-	if task.UserID != userID {
-		return nil, ErrUnauthorizedAccess
+	for _, reminder := range reminders {
+		if !reminder.IsRelative() || reminder.Status == models.ReminderStatusCancelled {
+			continue
+		}
+
+		offset := *reminder.OffsetMinutes
+		copied := &models.Reminder{
+			TaskID:        to.ID,
+			UserID:        reminder.UserID,
+			Channel:       reminder.Channel,
+			WebhookURL:    reminder.WebhookURL,
+			OffsetMinutes: &offset,
+			RemindAt:      to.DueDate.Add(-time.Duration(offset) * time.Minute),
+			Status:        models.ReminderStatusPending,
+		}
+		if err := reminderRepo.Create(copied); err != nil {
+			return err
+		}
 	}
 
-	response := TaskToResponseDTO(task)
-	return &response, nil
+	return nil
 }
 
-func (s *taskService) GetUserTasks(userID uint, pagination repositories.PaginationParams) (*TaskListResponseDTO, error) {
-	tasks, paginationResult, err := s.taskRepo.GetByUserID(userID, pagination)
+func (s *taskService) completionResponse(task, next *models.Task, warnings []string) (*TaskResponseDTO, error) {
+	response, err := s.taskResponse(task)
 	if err != nil {
 		return nil, err
 	}
 
-	return &TaskListResponseDTO{
-		Tasks:      TasksToResponseDTO(tasks),
-		Pagination: paginationResult,
-	}, nil
+	if next != nil {
+		summary := TaskToSummaryDTO(next)
+		response.NextOccurrence = &summary
+	}
+	response.Warnings = warnings
+	return response, nil
 }
 
-func (s *taskService) UpdateTask(userID, taskID uint, dto UpdateTaskDTO) (*TaskResponseDTO, error) {
-	task, err := s.taskRepo.GetByID(taskID)
+func normalizeRecurrence(rule, timezone string, dueDate *time.Time) (string, string, error) {
+	var validationErrors ValidationErrors
+
+	if timezone == "" {
+		timezone = defaultRecurrenceTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, ErrTaskNotFound
-		}
-		return nil, err
+		validationErrors.AddError("recurrence_timezone", "unknown time zone: "+timezone)
+		loc = time.UTC
 	}
 
-	if task.UserID != userID {
-		return nil, ErrUnauthorizedAccess
+	parsed, err := utils.ParseRecurrenceRule(rule, loc)
+	if err != nil {
+		validationErrors.AddError("recurrence_rule", err.Error())
 	}
 
-	if err := s.validateUpdateTask(dto, task); err != nil {
-		return nil, err
+	if dueDate == nil {
+		validationErrors.AddError("due_date", "recurring tasks need a due date")
 	}
 
-	s.applyUpdates(task, dto)
-
-	if err := s.taskRepo.Update(task); err != nil {
-		return nil, err
+	if validationErrors.HasErrors() {
+		return "", "", validationErrors
 	}
 
-	response := TaskToResponseDTO(task)
-	return &response, nil
+	return parsed.String(), timezone, nil
 }
 
-func (s *taskService) DeleteTask(userID, taskID uint) error {
-	task, err := s.taskRepo.GetByID(taskID)
+func nextOccurrenceDue(task *models.Task) (time.Time, bool, error) {
+	if task.DueDate == nil {
+		return time.Time{}, false, nil
+	}
+
+	loc, err := time.LoadLocation(task.RecurrenceTimezone)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrTaskNotFound
-		}
-		return err
+		return time.Time{}, false, err
 	}
 
-	if task.UserID != userID {
-		return ErrUnauthorizedAccess
+	rule, err := utils.ParseRecurrenceRule(task.RecurrenceRule, loc)
+	if err != nil {
+		return time.Time{}, false, err
 	}
 
-	return s.taskRepo.Delete(taskID)
+	next, ok := rule.NextOccurrence(*task.DueDate, task.RecurrenceIndex, loc)
+	return next, ok, nil
+}
+
+func (s *taskService) taskWorkflow(task *models.Task) (*TaskWorkflow, error) {
+	return loadTaskWorkflow(s.workflowRepo, task.WorkspaceID, task.ProjectID)
 }
 
-func (s *taskService) GetAllTasks(pagination repositories.PaginationParams) (*TaskListResponseDTO, error) {
-	tasks, paginationResult, err := s.taskRepo.List(pagination)
+func (s *taskService) completeDescendants(tx repositories.TaskTx, actorID, taskID uint) error {
+	descendants, err := tx.Tasks.ListOpenDescendants(taskID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &TaskListResponseDTO{
-		Tasks:      TasksToResponseDTO(tasks),
-		Pagination: paginationResult,
-	}, nil
+	for i := range descendants {
+		descendant := &descendants[i]
+		workflow, err := s.taskWorkflow(descendant)
+		if err != nil {
+			return err
+		}
+
+		before := snapshotTask(descendant)
+		done, _ := workflow.First(models.TaskStatusCategoryDone)
+		if err := s.stateMachine.Transition(descendant, workflow.Current(descendant), done); err != nil {
+			return err
+		}
+		if err := tx.Tasks.Update(descendant); err != nil {
+			return err
+		}
+		if err := s.recordActivity(tx.Activities, actorID, descendant, models.TaskActivityCompleted, before); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (s *taskService) CompleteTask(userID, taskID uint) (*TaskResponseDTO, error) {
-	task, err := s.taskRepo.GetByID(taskID)
+func (s *taskService) recordActivity(activities repositories.TaskActivityRepository, actorID uint, task *models.Task, action models.TaskActivityAction, before taskSnapshot) error {
+	changes := diffTaskSnapshots(before, snapshotTask(task))
+	if action == models.TaskActivityUpdated && len(changes) == 0 {
+		return nil
+	}
+
+	activity, err := newTaskActivity(actorID, task.ID, action, changes)
+	if err != nil {
+		return err
+	}
+	return activities.Create(&activity)
+}
+
+func (s *taskService) checkBlockers(task *models.Task, ignore bool) ([]string, error) {
+	blockers, err := s.dependencyRepo.ListOpenBlockerIDs([]uint{task.ID})
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, ErrTaskNotFound
-		}
 		return nil, err
 	}
 
-	if task.UserID != userID {
-		return nil, ErrUnauthorizedAccess
+	blockerIDs := blockers[task.ID]
+	if len(blockerIDs) == 0 {
+		return nil, nil
 	}
 
-	if task.Status == models.TaskStatusCompleted {
-		return nil, ErrTaskAlreadyCompleted
+	if !ignore {
+		return nil, TaskBlockedError{BlockerIDs: blockerIDs}
 	}
 
-	now := time.Now()
-	task.Status = models.TaskStatusCompleted
-	task.CompletedAt = &now
+	return []string{TaskBlockedError{BlockerIDs: blockerIDs}.Error()}, nil
+}
 
-	if err := s.taskRepo.Update(task); err != nil {
-		return nil, err
+func dependencyCreatesCycle(edges []models.TaskDependency, blockerID, blockedID uint) bool {
+	graph := make(map[uint][]uint)
+	for _, edge := range edges {
+		graph[edge.BlockerID] = append(graph[edge.BlockerID], edge.BlockedID)
 	}
 
-	response := TaskToResponseDTO(task)
-	return &response, nil
+	visited := map[uint]bool{blockedID: true}
+	stack := []uint{blockedID}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if current == blockerID {
+			return true
+		}
+
+		for _, next := range graph[current] {
+			if !visited[next] {
+				visited[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+
+	return false
 }
 
 func (s *taskService) validateCreateTask(dto CreateTaskDTO) error {
@@ -167,7 +1144,7 @@ func (s *taskService) validateCreateTask(dto CreateTaskDTO) error {
 	return nil
 }
 
-func (s *taskService) validateUpdateTask(dto UpdateTaskDTO, currentTask *models.Task) error {
+func (s *taskService) validateUpdateTask(dto UpdateTaskDTO) error {
 	var validationErrors ValidationErrors
 
 	if dto.Title != nil && strings.TrimSpace(*dto.Title) == "" {
@@ -178,10 +1155,6 @@ func (s *taskService) validateUpdateTask(dto UpdateTaskDTO, currentTask *models.
 		validationErrors.AddError("due_date", "due date cannot be in the past")
 	}
 
-	if dto.Status != nil && currentTask.Status == models.TaskStatusCompleted && *dto.Status != models.TaskStatusCompleted {
-		validationErrors.AddError("status", "cannot change status of completed task")
-	}
-
 	if validationErrors.HasErrors() {
 		return validationErrors
 	}
@@ -189,6 +1162,328 @@ func (s *taskService) validateUpdateTask(dto UpdateTaskDTO, currentTask *models.
 	return nil
 }
 
+func (s *taskService) resolveLabels(ownerID uint, ids []uint, names []string, createMissing bool) ([]models.Label, error) {
+	var labels []models.Label
+	seen := make(map[uint]bool)
+
+	uniqueLabelIDs := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			uniqueLabelIDs = append(uniqueLabelIDs, id)
+		}
+	}
+
+	if len(uniqueLabelIDs) > 0 {
+		found, err := s.labelRepo.ListByIDs(ownerID, uniqueLabelIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(found) != len(uniqueLabelIDs) {
+			return nil, NewValidationError("label_ids", "one or more labels do not exist")
+		}
+		labels = append(labels, found...)
+	}
+
+	var uniqueNames []string
+	requested := make(map[string]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if err := validateLabelName("labels", name); err != nil {
+			return nil, err
+		}
+		if !requested[name] {
+			requested[name] = true
+			uniqueNames = append(uniqueNames, name)
+		}
+	}
+
+	if len(uniqueNames) == 0 {
+		return labels, nil
+	}
+
+	existing, err := s.labelRepo.ListByNames(ownerID, uniqueNames)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]models.Label, len(existing))
+	for _, label := range existing {
+		byName[label.Name] = label
+	}
+
+	for _, name := range uniqueNames {
+		label, ok := byName[name]
+		if !ok {
+			if !createMissing {
+				return nil, NewValidationError("labels", "one or more labels do not exist; only the task owner can create labels")
+			}
+			label = models.Label{UserID: ownerID, Name: name, Color: models.DefaultLabelColor}
+			if err := s.labelRepo.Create(&label); err != nil {
+				return nil, err
+			}
+		}
+		if !seen[label.ID] {
+			seen[label.ID] = true
+			labels = append(labels, label)
+		}
+	}
+
+	return labels, nil
+}
+
+func (s *taskService) resolveLabelFilter(ownerID uint, values []string) ([]uint, error) {
+	var ids []uint
+	var names []string
+	for _, value := range values {
+		if id, err := strconv.ParseUint(value, 10, 32); err == nil {
+			ids = append(ids, uint(id))
+		} else {
+			names = append(names, value)
+		}
+	}
+
+	if len(names) == 0 {
+		return ids, nil
+	}
+
+	if ownerID == 0 {
+		return nil, NewValidationError("labels", "filter by label ID when listing tasks you do not own")
+	}
+
+	found, err := s.labelRepo.ListByNames(ownerID, names)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]uint, len(found))
+	for _, label := range found {
+		byName[label.Name] = label.ID
+	}
+
+	for _, name := range names {
+		id, ok := byName[name]
+		if !ok {
+			return nil, NewValidationError("labels", "unknown label: "+name)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (s *taskService) buildTaskFilter(ownerID uint, query TaskListQueryDTO, pagination repositories.PaginationParams) (repositories.TaskFilter, error) {
+	var validationErrors ValidationErrors
+	filter := repositories.TaskFilter{
+		Search:  strings.TrimSpace(query.Query),
+		Overdue: query.Overdue,
+		Blocked: query.Blocked,
+	}
+
+	if len(filter.Search) > maxSearchQueryLength {
+		validationErrors.AddError("q", "search query is too long")
+	}
+
+	for _, status := range splitQueryValues(query.Status) {
+		if !isValidStatusKey(status) {
+			validationErrors.AddError("status", "unknown status: "+status)
+			continue
+		}
+		filter.Statuses = append(filter.Statuses, models.TaskStatus(status))
+	}
+
+	for _, priority := range splitQueryValues(query.Priority) {
+		switch models.TaskPriority(priority) {
+		case models.TaskPriorityLow, models.TaskPriorityMedium, models.TaskPriorityHigh:
+			filter.Priorities = append(filter.Priorities, models.TaskPriority(priority))
+		default:
+			validationErrors.AddError("priority", "unknown priority: "+priority)
+		}
+	}
+
+	switch query.ParentID {
+	case "":
+	case "none":
+		filter.TopLevelOnly = true
+	default:
+		parentID, err := strconv.ParseUint(query.ParentID, 10, 32)
+		if err != nil {
+			validationErrors.AddError("parent_id", "must be a task ID or none")
+		} else {
+			id := uint(parentID)
+			filter.ParentID = &id
+		}
+	}
+
+	switch query.ProjectID {
+	case "":
+	case "none":
+		filter.WithoutProject = true
+	default:
+		projectID, err := strconv.ParseUint(query.ProjectID, 10, 32)
+		if err != nil {
+			validationErrors.AddError("project_id", "must be a project ID or none")
+		} else {
+			id := uint(projectID)
+			filter.ProjectID = &id
+		}
+	}
+	filter.IncludeArchived = query.IncludeArchived
+
+	if labelValues := splitQueryValues(query.Labels); len(labelValues) > 0 {
+		labelIDs, err := s.resolveLabelFilter(ownerID, labelValues)
+		if err != nil {
+			if labelErr, ok := err.(ValidationErrors); ok {
+				validationErrors.Errors = append(validationErrors.Errors, labelErr.Errors...)
+			} else {
+				return repositories.TaskFilter{}, err
+			}
+		}
+		filter.LabelIDs = labelIDs
+	}
+
+	switch repositories.LabelMatch(query.LabelMatch) {
+	case "", repositories.LabelMatchAny:
+		filter.LabelMatch = repositories.LabelMatchAny
+	case repositories.LabelMatchAll:
+		filter.LabelMatch = repositories.LabelMatchAll
+	default:
+		validationErrors.AddError("label_match", "must be any or all")
+	}
+
+	dateParams := []struct {
+		field  string
+		value  string
+		target **time.Time
+	}{
+		{"due_after", query.DueAfter, &filter.DueAfter},
+		{"due_before", query.DueBefore, &filter.DueBefore},
+		{"completed_after", query.CompletedAfter, &filter.CompletedAfter},
+		{"completed_before", query.CompletedBefore, &filter.CompletedBefore},
+		{"created_after", query.CreatedAfter, &filter.CreatedAfter},
+		{"created_before", query.CreatedBefore, &filter.CreatedBefore},
+		{"updated_after", query.UpdatedAfter, &filter.UpdatedAfter},
+		{"updated_before", query.UpdatedBefore, &filter.UpdatedBefore},
+	}
+	for _, param := range dateParams {
+		if param.value == "" {
+			continue
+		}
+		parsed, err := parseQueryTime(param.value)
+		if err != nil {
+			validationErrors.AddError(param.field, "must be an RFC 3339 timestamp or YYYY-MM-DD date")
+			continue
+		}
+		*param.target = &parsed
+	}
+
+	for _, key := range splitQueryValues([]string{query.Sort}) {
+		sort := repositories.TaskSort{Field: repositories.TaskSortField(strings.TrimPrefix(key, "-")), Descending: strings.HasPrefix(key, "-")}
+		if !repositories.IsValidTaskSortField(sort.Field) {
+			validationErrors.AddError("sort", "cannot sort by "+string(sort.Field))
+			continue
+		}
+		filter.Sort = append(filter.Sort, sort)
+	}
+
+	if pagination.After != "" && pagination.Before != "" {
+		validationErrors.AddError("before", "cannot be combined with after")
+	}
+
+	if validationErrors.HasErrors() {
+		return repositories.TaskFilter{}, validationErrors
+	}
+
+	return filter, nil
+}
+
+func invalidCursorError(pagination repositories.PaginationParams) error {
+	if pagination.Before != "" {
+		return NewValidationError("before", "cursor is invalid or was issued for a different sort or search")
+	}
+	return NewValidationError("after", "cursor is invalid or was issued for a different sort or search")
+}
+
+func (s *taskService) taskResponse(task *models.Task) (*TaskResponseDTO, error) {
+	responses, err := s.taskResponses([]models.Task{*task})
+	if err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
+}
+
+func (s *taskService) taskResponses(tasks []models.Task) ([]TaskResponseDTO, error) {
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
+	progress, err := s.taskRepo.GetProgress(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	blockers, err := s.dependencyRepo.ListOpenBlockerIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	commentCounts, err := s.commentRepo.CountByTaskIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := TasksToResponseDTO(tasks)
+	for i := range responses {
+		responses[i].Progress = TaskProgressToDTO(progress[tasks[i].ID])
+		responses[i].BlockedBy = blockers[tasks[i].ID]
+		responses[i].Blocked = len(responses[i].BlockedBy) > 0
+		responses[i].CommentCount = commentCounts[tasks[i].ID]
+	}
+	return responses, nil
+}
+
+func (s *taskService) taskListResponse(tasks []models.Task, filter repositories.TaskFilter, pagination repositories.PaginationResult) (*TaskListResponseDTO, error) {
+	responses, err := s.taskResponses(tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Search != "" {
+		for i := range tasks {
+			responses[i].Search = &TaskSearchMatchDTO{
+				Rank:    tasks[i].SearchRank,
+				Title:   tasks[i].SearchTitle,
+				Snippet: tasks[i].SearchSnippet,
+			}
+		}
+	}
+
+	return &TaskListResponseDTO{
+		Tasks:      responses,
+		Pagination: pagination,
+	}, nil
+}
+
+func splitQueryValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
+
+func parseQueryTime(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
 func (s *taskService) applyUpdates(task *models.Task, dto UpdateTaskDTO) {
 	if dto.Title != nil {
 		task.Title = *dto.Title
@@ -196,19 +1491,103 @@ func (s *taskService) applyUpdates(task *models.Task, dto UpdateTaskDTO) {
 	if dto.Description != nil {
 		task.Description = *dto.Description
 	}
-	if dto.Status != nil {
-		task.Status = *dto.Status
-		if *dto.Status == models.TaskStatusCompleted && task.CompletedAt == nil {
-			now := time.Now()
-			task.CompletedAt = &now
-		} else if *dto.Status != models.TaskStatusCompleted {
-			task.CompletedAt = nil
-		}
-	}
 	if dto.Priority != nil {
 		task.Priority = *dto.Priority
 	}
 	if dto.DueDate != nil {
 		task.DueDate = dto.DueDate
 	}
+	if dto.ParentID != nil {
+		if *dto.ParentID == 0 {
+			task.ParentID = nil
+		} else {
+			parentID := *dto.ParentID
+			task.ParentID = &parentID
+		}
+	}
+	if dto.ProjectID != nil {
+		if *dto.ProjectID == 0 {
+			task.ProjectID = nil
+		} else {
+			projectID := *dto.ProjectID
+			task.ProjectID = &projectID
+		}
+	}
+	if dto.AssigneeID != nil {
+		if *dto.AssigneeID == 0 {
+			task.AssigneeID = nil
+		} else {
+			assigneeID := *dto.AssigneeID
+			task.AssigneeID = &assigneeID
+		}
+	}
+}
+
+func (s *taskService) getParentTask(ownerID, parentID uint) (*models.Task, error) {
+	parent, err := s.taskRepo.GetByID(parentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, NewValidationError("parent_id", "parent task does not exist")
+		}
+		return nil, err
+	}
+
+	if parent.UserID != ownerID {
+		return nil, NewValidationError("parent_id", "parent task does not exist")
+	}
+
+	return parent, nil
+}
+
+func (s *taskService) checkNewParent(task *models.Task, parentID uint) error {
+	if parentID == task.ID {
+		return NewValidationError("parent_id", "a task cannot be its own parent")
+	}
+
+	if _, err := s.getParentTask(task.UserID, parentID); err != nil {
+		return err
+	}
+
+	descendantIDs, err := s.taskRepo.ListDescendantIDs(task.ID)
+	if err != nil {
+		return err
+	}
+	for _, id := range descendantIDs {
+		if id == parentID {
+			return NewValidationError("parent_id", "a task cannot be moved under one of its own subtasks")
+		}
+	}
+
+	return nil
+}
+
+func (s *taskService) checkTaskProject(ownerID, projectID uint) error {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return NewValidationError("project_id", "project does not exist")
+		}
+		return err
+	}
+
+	if project.UserID != ownerID {
+		return NewValidationError("project_id", "project does not exist")
+	}
+
+	if project.Archived {
+		return ErrProjectArchived
+	}
+
+	return nil
+}
+
+func (s *taskService) checkAssignee(assigneeID uint) error {
+	if _, err := s.workspaceRepo.GetMember(s.workspaceID, assigneeID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return NewValidationError("assignee_id", "user is not a member of this workspace")
+		}
+		return err
+	}
+
+	return nil
 }
\ No newline at end of file
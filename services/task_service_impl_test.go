@@ -0,0 +1,40 @@
+package services
+
+import (
+	"testing"
+
+	"task-api/models"
+)
+
+func TestDependencyCreatesCycle(t *testing.T) {
+	edge := func(blocker, blocked uint) models.TaskDependency {
+		return models.TaskDependency{BlockerID: blocker, BlockedID: blocked}
+	}
+
+	tests := []struct {
+		name    string
+		edges   []models.TaskDependency
+		blocker uint
+		blocked uint
+		want    bool
+	}{
+		{"no existing edges", nil, 1, 2, false},
+		{"task blocking itself", nil, 1, 1, true},
+		{"direct reverse edge", []models.TaskDependency{edge(2, 1)}, 1, 2, true},
+		{"duplicate of an existing edge", []models.TaskDependency{edge(1, 2)}, 1, 2, false},
+		{"transitive chain back to the blocker", []models.TaskDependency{edge(2, 3), edge(3, 4), edge(4, 1)}, 1, 2, true},
+		{"chain that never reaches the blocker", []models.TaskDependency{edge(2, 3), edge(3, 4), edge(5, 1)}, 1, 2, false},
+		{"diamond without a cycle", []models.TaskDependency{edge(1, 2), edge(1, 3), edge(2, 4), edge(3, 4)}, 4, 5, false},
+		{"diamond closed by the new edge", []models.TaskDependency{edge(1, 2), edge(1, 3), edge(2, 4), edge(3, 4)}, 4, 1, true},
+		{"existing cycle elsewhere in the graph", []models.TaskDependency{edge(5, 6), edge(6, 5), edge(2, 3)}, 1, 2, false},
+		{"blocker only reachable upstream", []models.TaskDependency{edge(1, 2), edge(3, 1)}, 3, 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dependencyCreatesCycle(tt.edges, tt.blocker, tt.blocked); got != tt.want {
+				t.Errorf("dependencyCreatesCycle(%d blocks %d) = %v, want %v", tt.blocker, tt.blocked, got, tt.want)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"task-api/models"
+	"time"
+)
+
+type ShareTaskDTO struct {
+	UserID     *uint                      `json:"user_id,omitempty"`
+	Email      string                     `json:"email,omitempty" binding:"omitempty,email"`
+	Permission models.TaskSharePermission `json:"permission" binding:"required,oneof=viewer editor"`
+}
+
+type TaskShareResponseDTO struct {
+	UserID     uint                       `json:"user_id"`
+	Email      string                     `json:"email"`
+	FirstName  string                     `json:"first_name"`
+	LastName   string                     `json:"last_name"`
+	Permission models.TaskSharePermission `json:"permission"`
+	CreatedAt  time.Time                  `json:"created_at"`
+	UpdatedAt  time.Time                  `json:"updated_at"`
+}
+
+func TaskShareToResponseDTO(share *models.TaskShare) TaskShareResponseDTO {
+	return TaskShareResponseDTO{
+		UserID:     share.UserID,
+		Email:      share.User.Email,
+		FirstName:  share.User.FirstName,
+		LastName:   share.User.LastName,
+		Permission: share.Permission,
+		CreatedAt:  share.CreatedAt,
+		UpdatedAt:  share.UpdatedAt,
+	}
+}
+
+func TaskSharesToResponseDTO(shares []models.TaskShare) []TaskShareResponseDTO {
+	result := make([]TaskShareResponseDTO, len(shares))
+	for i, share := range shares {
+		result[i] = TaskShareToResponseDTO(&share)
+	}
+	return result
+}
\ No newline at end of file
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"task-api/models"
+	"task-api/repositories"
+)
+
+type InvalidTransitionError struct {
+	From    models.TaskStatus
+	To      models.TaskStatus
+	Allowed []models.TaskStatus
+}
+
+func (e InvalidTransitionError) Error() string {
+	return fmt.Sprintf("cannot move task from %s to %s", e.From, e.To)
+}
+
+type WorkflowSource string
+
+const (
+	WorkflowSourceDefault   WorkflowSource = "default"
+	WorkflowSourceWorkspace WorkflowSource = "workspace"
+	WorkflowSourceProject   WorkflowSource = "project"
+)
+
+var defaultWorkflowStatuses = []models.WorkflowStatus{
+	{Key: models.TaskStatusPending, Name: "Pending", Category: models.TaskStatusCategoryTodo, Position: 0, Transitions: "in_progress,completed,cancelled"},
+	{Key: models.TaskStatusInProgress, Name: "In Progress", Category: models.TaskStatusCategoryInProgress, Position: 1, Transitions: "pending,completed,cancelled"},
+	{Key: models.TaskStatusCompleted, Name: "Completed", Category: models.TaskStatusCategoryDone, Position: 2},
+	{Key: models.TaskStatusCancelled, Name: "Cancelled", Category: models.TaskStatusCategoryCancelled, Position: 3, Transitions: "pending,in_progress,completed"},
+}
+
+// TaskWorkflow is the set of statuses tasks of a project or workspace can be
+// in, in display order, with the transitions allowed between them.
+type TaskWorkflow struct {
+	Source   WorkflowSource
+	Statuses []models.WorkflowStatus
+}
+
+func DefaultTaskWorkflow() *TaskWorkflow {
+	statuses := make([]models.WorkflowStatus, len(defaultWorkflowStatuses))
+	copy(statuses, defaultWorkflowStatuses)
+	return &TaskWorkflow{Source: WorkflowSourceDefault, Statuses: statuses}
+}
+
+func loadTaskWorkflow(workflowRepo repositories.WorkflowRepository, workspaceID uint, projectID *uint) (*TaskWorkflow, error) {
+	if projectID != nil {
+		statuses, err := workflowRepo.ListByProject(*projectID)
+		if err != nil {
+			return nil, err
+		}
+		if len(statuses) > 0 {
+			return &TaskWorkflow{Source: WorkflowSourceProject, Statuses: statuses}, nil
+		}
+	}
+
+	statuses, err := workflowRepo.ListByWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if len(statuses) > 0 {
+		return &TaskWorkflow{Source: WorkflowSourceWorkspace, Statuses: statuses}, nil
+	}
+
+	return DefaultTaskWorkflow(), nil
+}
+
+func (w *TaskWorkflow) Status(key models.TaskStatus) (models.WorkflowStatus, bool) {
+	for _, status := range w.Statuses {
+		if status.Key == key {
+			return status, true
+		}
+	}
+	return models.WorkflowStatus{}, false
+}
+
+func (w *TaskWorkflow) First(category models.TaskStatusCategory) (models.WorkflowStatus, bool) {
+	for _, status := range w.Statuses {
+		if status.Category == category {
+			return status, true
+		}
+	}
+	return models.WorkflowStatus{}, false
+}
+
+func (w *TaskWorkflow) Initial() models.WorkflowStatus {
+	status, _ := w.First(models.TaskStatusCategoryTodo)
+	return status
+}
+
+// Current returns the status a task is in. A task whose status is not part of
+// the workflow, for example after its project was deleted, is treated as being
+// in the first status of its category.
+func (w *TaskWorkflow) Current(task *models.Task) models.WorkflowStatus {
+	if status, ok := w.Status(task.Status); ok && status.Category == task.StatusCategory {
+		return status
+	}
+	if status, ok := w.First(task.StatusCategory); ok {
+		return status
+	}
+	return w.Initial()
+}
+
+func (w *TaskWorkflow) Allowed(from models.WorkflowStatus) []models.TaskStatus {
+	allowed := []models.TaskStatus{}
+	for _, key := range from.TransitionList() {
+		if _, ok := w.Status(key); ok {
+			allowed = append(allowed, key)
+		}
+	}
+	return allowed
+}
+
+func (w *TaskWorkflow) CanTransition(from models.WorkflowStatus, to models.TaskStatus) bool {
+	for _, key := range w.Allowed(from) {
+		if key == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns the status a task in from may move to, or an
+// InvalidTransitionError listing the allowed ones.
+func (w *TaskWorkflow) Check(from models.WorkflowStatus, to models.TaskStatus) (models.WorkflowStatus, error) {
+	if to == from.Key {
+		return from, nil
+	}
+
+	target, ok := w.Status(to)
+	if !ok || !w.CanTransition(from, to) {
+		return models.WorkflowStatus{}, InvalidTransitionError{From: from.Key, To: to, Allowed: w.Allowed(from)}
+	}
+	return target, nil
+}
+
+// NextInCategory returns the first status of a category that from may move to.
+func (w *TaskWorkflow) NextInCategory(from models.WorkflowStatus, category models.TaskStatusCategory) (models.WorkflowStatus, bool) {
+	for _, status := range w.Statuses {
+		if status.Category == category && w.CanTransition(from, status.Key) {
+			return status, true
+		}
+	}
+	return models.WorkflowStatus{}, false
+}
+
+type TransitionHook func(task *models.Task, from, to models.WorkflowStatus) error
+
+// TaskStateMachine moves tasks between the statuses of their workflow. Hooks
+// are registered per category and run when a task leaves or enters it; moves
+// between two statuses of the same category run none.
+type TaskStateMachine struct {
+	onEnter map[models.TaskStatusCategory][]TransitionHook
+	onExit  map[models.TaskStatusCategory][]TransitionHook
+}
+
+func NewTaskStateMachine() *TaskStateMachine {
+	m := &TaskStateMachine{
+		onEnter: make(map[models.TaskStatusCategory][]TransitionHook),
+		onExit:  make(map[models.TaskStatusCategory][]TransitionHook),
+	}
+
+	m.OnEnter(models.TaskStatusCategoryDone, func(task *models.Task, from, to models.WorkflowStatus) error {
+		now := time.Now()
+		task.CompletedAt = &now
+		return nil
+	})
+	m.OnExit(models.TaskStatusCategoryDone, func(task *models.Task, from, to models.WorkflowStatus) error {
+		task.CompletedAt = nil
+		return nil
+	})
+
+	return m
+}
+
+func (m *TaskStateMachine) OnEnter(category models.TaskStatusCategory, hook TransitionHook) {
+	m.onEnter[category] = append(m.onEnter[category], hook)
+}
+
+func (m *TaskStateMachine) OnExit(category models.TaskStatusCategory, hook TransitionHook) {
+	m.onExit[category] = append(m.onExit[category], hook)
+}
+
+// Transition moves a task from one status to another and runs the hooks of
+// the categories it leaves and enters. Callers check the move with
+// TaskWorkflow.Check first unless they deliberately bypass the workflow.
+func (m *TaskStateMachine) Transition(task *models.Task, from, to models.WorkflowStatus) error {
+	changesCategory := from.Category != to.Category
+	if changesCategory {
+		for _, hook := range m.onExit[from.Category] {
+			if err := hook(task, from, to); err != nil {
+				return err
+			}
+		}
+	}
+
+	task.Status = to.Key
+	task.StatusCategory = to.Category
+
+	if changesCategory {
+		for _, hook := range m.onEnter[to.Category] {
+			if err := hook(task, from, to); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"task-api/models"
+)
+
+func TestTaskWorkflowCheck(t *testing.T) {
+	custom := &TaskWorkflow{Source: WorkflowSourceProject, Statuses: []models.WorkflowStatus{
+		{Key: "backlog", Category: models.TaskStatusCategoryTodo, Transitions: "doing,archived"},
+		{Key: "doing", Category: models.TaskStatusCategoryInProgress, Transitions: "review,backlog"},
+		{Key: "review", Category: models.TaskStatusCategoryInProgress, Transitions: "doing,shipped"},
+		{Key: "shipped", Category: models.TaskStatusCategoryDone},
+	}}
+
+	tests := []struct {
+		name     string
+		workflow *TaskWorkflow
+		from     models.TaskStatus
+		to       models.TaskStatus
+		want     models.TaskStatus
+		allowed  []models.TaskStatus
+	}{
+		{"default pending to in progress", DefaultTaskWorkflow(), models.TaskStatusPending, models.TaskStatusInProgress, models.TaskStatusInProgress, nil},
+		{"default pending to completed", DefaultTaskWorkflow(), models.TaskStatusPending, models.TaskStatusCompleted, models.TaskStatusCompleted, nil},
+		{"default cancelled reopened", DefaultTaskWorkflow(), models.TaskStatusCancelled, models.TaskStatusPending, models.TaskStatusPending, nil},
+		{"default completed is final", DefaultTaskWorkflow(), models.TaskStatusCompleted, models.TaskStatusPending, "", []models.TaskStatus{}},
+		{"staying in a final status", DefaultTaskWorkflow(), models.TaskStatusCompleted, models.TaskStatusCompleted, models.TaskStatusCompleted, nil},
+		{"unknown target status", DefaultTaskWorkflow(), models.TaskStatusPending, "archived", "", []models.TaskStatus{models.TaskStatusInProgress, models.TaskStatusCompleted, models.TaskStatusCancelled}},
+		{"custom move within a category", custom, "doing", "review", "review", nil},
+		{"custom skip not listed", custom, "backlog", "shipped", "", []models.TaskStatus{"doing"}},
+		{"custom transition to a removed status", custom, "backlog", "archived", "", []models.TaskStatus{"doing"}},
+		{"custom move back", custom, "review", "doing", "doing", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, ok := tt.workflow.Status(tt.from)
+			if !ok {
+				t.Fatalf("status %s not in workflow", tt.from)
+			}
+
+			got, err := tt.workflow.Check(from, tt.to)
+			if tt.allowed == nil {
+				if err != nil {
+					t.Fatalf("Check(%s, %s) returned %v", tt.from, tt.to, err)
+				}
+				if got.Key != tt.want {
+					t.Errorf("Check(%s, %s) = %s, want %s", tt.from, tt.to, got.Key, tt.want)
+				}
+				return
+			}
+
+			var transitionErr InvalidTransitionError
+			if !errors.As(err, &transitionErr) {
+				t.Fatalf("Check(%s, %s) error = %v, want InvalidTransitionError", tt.from, tt.to, err)
+			}
+			if transitionErr.From != tt.from || transitionErr.To != tt.to {
+				t.Errorf("error reports %s -> %s, want %s -> %s", transitionErr.From, transitionErr.To, tt.from, tt.to)
+			}
+			if !reflect.DeepEqual(transitionErr.Allowed, tt.allowed) {
+				t.Errorf("allowed = %v, want %v", transitionErr.Allowed, tt.allowed)
+			}
+		})
+	}
+}
\ No newline at end of file
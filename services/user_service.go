@@ -0,0 +1,11 @@
+package services
+
+import (
+	"task-api/repositories"
+)
+
+type UserService interface {
+	ListUsers(pagination repositories.PaginationParams) (*UserListResponseDTO, error)
+	UpdateUserRole(actorID, userID uint, dto UpdateUserRoleDTO) (*UserResponseDTO, error)
+	UnlockUser(userID uint) error
+}
\ No newline at end of file
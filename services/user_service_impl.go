@@ -0,0 +1,96 @@
+package services
+
+import (
+	"errors"
+
+	"task-api/models"
+	"task-api/repositories"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidRole         = errors.New("invalid role")
+	ErrCannotChangeOwnRole = errors.New("cannot change own role")
+)
+
+type userService struct {
+	userRepo         repositories.UserRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	sessionRepo      repositories.SessionRepository
+	loginAttemptRepo repositories.LoginAttemptRepository
+}
+
+func NewUserService(userRepo repositories.UserRepository, refreshTokenRepo repositories.RefreshTokenRepository, sessionRepo repositories.SessionRepository, loginAttemptRepo repositories.LoginAttemptRepository) UserService {
+	return &userService{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		sessionRepo:      sessionRepo,
+		loginAttemptRepo: loginAttemptRepo,
+	}
+}
+
+func (s *userService) ListUsers(pagination repositories.PaginationParams) (*UserListResponseDTO, error) {
+	users, paginationResult, err := s.userRepo.List(pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserListResponseDTO{
+		Users:      UsersToResponseDTO(users),
+		Pagination: paginationResult,
+	}, nil
+}
+
+func (s *userService) UpdateUserRole(actorID, userID uint, dto UpdateUserRoleDTO) (*UserResponseDTO, error) {
+	role := models.UserRole(dto.Role)
+	if !role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	if actorID == userID {
+		return nil, ErrCannotChangeOwnRole
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if user.Role == role {
+		response := UserToResponseDTO(user)
+		return &response, nil
+	}
+
+	user.Role = role
+
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	// Access tokens carry the role as a claim, so the user has to sign in
+	// again to pick up the new one.
+	if err := s.refreshTokenRepo.RevokeAllForUser(user.ID); err != nil {
+		return nil, err
+	}
+	if err := s.sessionRepo.RevokeAllForUser(user.ID); err != nil {
+		return nil, err
+	}
+
+	response := UserToResponseDTO(user)
+	return &response, nil
+}
+
+func (s *userService) UnlockUser(userID uint) error {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	return s.loginAttemptRepo.Reset(accountThrottleKey(userID))
+}
\ No newline at end of file
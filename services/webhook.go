@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+const webhookResolveTimeout = 5 * time.Second
+
+var ErrWebhookAddressNotAllowed = errors.New("webhook address is not allowed")
+
+var nonPublicWebhookPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// isPublicWebhookAddr reports whether webhooks may be delivered to addr. It
+// rejects loopback, private, link-local (including the 169.254.169.254
+// metadata server) and other non-routable addresses.
+func isPublicWebhookAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublicWebhookPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkWebhookURL resolves the webhook host and rejects URLs that point at a
+// non-public address. Delivery checks the dialled address again, since DNS
+// answers can change after validation.
+func checkWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Hostname() == "" {
+		return ErrWebhookAddressNotAllowed
+	}
+
+	if addr, err := netip.ParseAddr(parsed.Hostname()); err == nil {
+		if !isPublicWebhookAddr(addr) {
+			return ErrWebhookAddressNotAllowed
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookResolveTimeout)
+	defer cancel()
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", parsed.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if !isPublicWebhookAddr(addr) {
+			return ErrWebhookAddressNotAllowed
+		}
+	}
+	return nil
+}
+
+// NewWebhookHTTPClient returns a client for delivering webhooks that only
+// connects to public addresses, ignores proxy settings and does not follow
+// redirects.
+func NewWebhookHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !isPublicWebhookAddr(addrPort.Addr()) {
+				return ErrWebhookAddressNotAllowed
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
\ No newline at end of file
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestIsPublicWebhookAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"8.8.8.8", true},
+		{"2606:4700:4700::1111", true},
+		{"127.0.0.1", false},
+		{"127.10.0.1", false},
+		{"::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"10.0.0.5", false},
+		{"172.16.3.4", false},
+		{"192.168.1.1", false},
+		{"fd00::1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"100.64.0.1", false},
+		{"198.18.0.1", false},
+		{"224.0.0.1", false},
+		{"ff02::1", false},
+		{"255.255.255.255", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+		{"64:ff9b::a9fe:a9fe", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := isPublicWebhookAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("isPublicWebhookAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckWebhookURLRejectsNonPublicLiterals(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"no host", "https:///hook"},
+		{"unparseable", "http://[::1"},
+		{"loopback", "http://127.0.0.1:8080/hook"},
+		{"metadata server", "http://169.254.169.254/latest/meta-data/"},
+		{"private IPv6", "https://[fd00::1]/hook"},
+		{"mapped loopback", "http://[::ffff:127.0.0.1]/hook"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkWebhookURL(tt.url); !errors.Is(err, ErrWebhookAddressNotAllowed) {
+				t.Errorf("checkWebhookURL(%q) error = %v, want ErrWebhookAddressNotAllowed", tt.url, err)
+			}
+		})
+	}
+
+	if err := checkWebhookURL("https://93.184.216.34/hook"); err != nil {
+		t.Errorf("checkWebhookURL with a public address: %v", err)
+	}
+}
+
+func TestWebhookHTTPClientRefusesLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("webhook client reached a loopback server")
+	}))
+	defer server.Close()
+
+	_, err := NewWebhookHTTPClient(time.Second).Get(server.URL)
+	if !errors.Is(err, ErrWebhookAddressNotAllowed) {
+		t.Errorf("Get(%s) error = %v, want ErrWebhookAddressNotAllowed", server.URL, err)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"task-api/models"
+)
+
+type WorkflowStatusDTO struct {
+	Key         string                    `json:"key" binding:"required,min=1,max=20"`
+	Name        string                    `json:"name" binding:"required,min=1,max=50"`
+	Category    models.TaskStatusCategory `json:"category" binding:"required,oneof=todo in_progress done cancelled"`
+	Transitions *[]string                 `json:"transitions,omitempty" binding:"omitempty,dive,min=1,max=20"`
+}
+
+type ReplaceWorkflowDTO struct {
+	Statuses []WorkflowStatusDTO `json:"statuses" binding:"required,min=1,max=20,dive"`
+}
+
+type WorkflowStatusResponseDTO struct {
+	Key         models.TaskStatus         `json:"key"`
+	Name        string                    `json:"name"`
+	Category    models.TaskStatusCategory `json:"category"`
+	Position    int                       `json:"position"`
+	Transitions []models.TaskStatus       `json:"transitions"`
+}
+
+type WorkflowResponseDTO struct {
+	Source    WorkflowSource              `json:"source"`
+	ProjectID *uint                       `json:"project_id,omitempty"`
+	Statuses  []WorkflowStatusResponseDTO `json:"statuses"`
+}
+
+func WorkflowToResponseDTO(workflow *TaskWorkflow, projectID *uint) WorkflowResponseDTO {
+	statuses := make([]WorkflowStatusResponseDTO, len(workflow.Statuses))
+	for i, status := range workflow.Statuses {
+		statuses[i] = WorkflowStatusResponseDTO{
+			Key:         status.Key,
+			Name:        status.Name,
+			Category:    status.Category,
+			Position:    status.Position,
+			Transitions: workflow.Allowed(status),
+		}
+	}
+
+	return WorkflowResponseDTO{
+		Source:    workflow.Source,
+		ProjectID: projectID,
+		Statuses:  statuses,
+	}
+}
\ No newline at end of file
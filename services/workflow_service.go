@@ -0,0 +1,11 @@
+package services
+
+type WorkflowService interface {
+	InWorkspace(workspaceID uint) WorkflowService
+	GetWorkspaceWorkflow() (*WorkflowResponseDTO, error)
+	ReplaceWorkspaceWorkflow(userID uint, dto ReplaceWorkflowDTO) (*WorkflowResponseDTO, error)
+	ResetWorkspaceWorkflow(userID uint) (*WorkflowResponseDTO, error)
+	GetProjectWorkflow(userID, projectID uint) (*WorkflowResponseDTO, error)
+	ReplaceProjectWorkflow(userID, projectID uint, dto ReplaceWorkflowDTO) (*WorkflowResponseDTO, error)
+	ResetProjectWorkflow(userID, projectID uint) (*WorkflowResponseDTO, error)
+}
\ No newline at end of file
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"task-api/models"
+	"task-api/repositories"
+
+	"gorm.io/gorm"
+)
+
+var statusKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,19}$`)
+
+func isValidStatusKey(key string) bool {
+	return statusKeyPattern.MatchString(key)
+}
+
+type workflowService struct {
+	workflowRepo repositories.WorkflowRepository
+	projectRepo  repositories.ProjectRepository
+	workspaceID  uint
+}
+
+func NewWorkflowService(workflowRepo repositories.WorkflowRepository, projectRepo repositories.ProjectRepository) WorkflowService {
+	return &workflowService{
+		workflowRepo: workflowRepo,
+		projectRepo:  projectRepo,
+	}
+}
+
+func (s *workflowService) InWorkspace(workspaceID uint) WorkflowService {
+	return &workflowService{
+		workflowRepo: s.workflowRepo.InWorkspace(workspaceID),
+		projectRepo:  s.projectRepo.InWorkspace(workspaceID),
+		workspaceID:  workspaceID,
+	}
+}
+
+func (s *workflowService) GetWorkspaceWorkflow() (*WorkflowResponseDTO, error) {
+	return s.workflowResponse(nil)
+}
+
+func (s *workflowService) ReplaceWorkspaceWorkflow(userID uint, dto ReplaceWorkflowDTO) (*WorkflowResponseDTO, error) {
+	return s.replace(userID, nil, dto)
+}
+
+func (s *workflowService) ResetWorkspaceWorkflow(userID uint) (*WorkflowResponseDTO, error) {
+	return s.reset(userID, nil, DefaultTaskWorkflow())
+}
+
+func (s *workflowService) GetProjectWorkflow(userID, projectID uint) (*WorkflowResponseDTO, error) {
+	project, err := s.getOwnedProject(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.workflowResponse(&project.ID)
+}
+
+func (s *workflowService) ReplaceProjectWorkflow(userID, projectID uint, dto ReplaceWorkflowDTO) (*WorkflowResponseDTO, error) {
+	project, err := s.getOwnedProject(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.replace(userID, &project.ID, dto)
+}
+
+func (s *workflowService) ResetProjectWorkflow(userID, projectID uint) (*WorkflowResponseDTO, error) {
+	project, err := s.getOwnedProject(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	inherited, err := loadTaskWorkflow(s.workflowRepo, s.workspaceID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reset(userID, &project.ID, inherited)
+}
+
+func (s *workflowService) replace(actorID uint, projectID *uint, dto ReplaceWorkflowDTO) (*WorkflowResponseDTO, error) {
+	statuses, err := s.buildStatuses(projectID, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	remap, err := s.remapStatuses(projectID, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.workflowRepo.Replace(s.workspaceID, projectID, statuses, remap, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.workflowResponse(projectID)
+}
+
+func (s *workflowService) reset(actorID uint, projectID *uint, inherited *TaskWorkflow) (*WorkflowResponseDTO, error) {
+	remap, err := s.remapStatuses(projectID, inherited.Statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.workflowRepo.Replace(s.workspaceID, projectID, nil, remap, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.workflowResponse(projectID)
+}
+
+func (s *workflowService) buildStatuses(projectID *uint, dto ReplaceWorkflowDTO) ([]models.WorkflowStatus, error) {
+	var validationErrors ValidationErrors
+
+	keys := make(map[string]bool, len(dto.Statuses))
+	categories := make(map[models.TaskStatusCategory]bool)
+	for _, status := range dto.Statuses {
+		key := strings.TrimSpace(status.Key)
+		if !isValidStatusKey(key) {
+			validationErrors.AddError("statuses", fmt.Sprintf("status key %q must start with a lowercase letter and contain only lowercase letters, digits and underscores", key))
+		} else if keys[key] {
+			validationErrors.AddError("statuses", fmt.Sprintf("status key %q is used more than once", key))
+		}
+		keys[key] = true
+		categories[status.Category] = true
+	}
+
+	for _, category := range models.TaskStatusCategories {
+		if !categories[category] {
+			validationErrors.AddError("statuses", fmt.Sprintf("workflow needs at least one %s status", category))
+		}
+	}
+
+	statuses := make([]models.WorkflowStatus, len(dto.Statuses))
+	for i, status := range dto.Statuses {
+		key := strings.TrimSpace(status.Key)
+
+		var transitions []string
+		if status.Transitions != nil {
+			seen := make(map[string]bool)
+			for _, next := range *status.Transitions {
+				next = strings.TrimSpace(next)
+				switch {
+				case next == key:
+					validationErrors.AddError("statuses", fmt.Sprintf("status %q cannot transition to itself", key))
+				case !keys[next]:
+					validationErrors.AddError("statuses", fmt.Sprintf("status %q transitions to unknown status %q", key, next))
+				case !seen[next]:
+					seen[next] = true
+					transitions = append(transitions, next)
+				}
+			}
+		} else if status.Category != models.TaskStatusCategoryDone {
+			for _, other := range dto.Statuses {
+				if otherKey := strings.TrimSpace(other.Key); otherKey != key {
+					transitions = append(transitions, otherKey)
+				}
+			}
+		}
+
+		statuses[i] = models.WorkflowStatus{
+			WorkspaceID: s.workspaceID,
+			ProjectID:   projectID,
+			Key:         models.TaskStatus(key),
+			Name:        strings.TrimSpace(status.Name),
+			Category:    status.Category,
+			Position:    i,
+			Transitions: strings.Join(transitions, ","),
+		}
+	}
+
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+
+	return statuses, nil
+}
+
+// remapStatuses moves tasks out of statuses the new workflow drops, into the
+// first status of the same category. Statuses in use cannot change category,
+// since that would complete or reopen tasks behind their owners' backs.
+func (s *workflowService) remapStatuses(projectID *uint, statuses []models.WorkflowStatus) (map[models.TaskStatus]models.TaskStatus, error) {
+	usage, err := s.workflowRepo.ListStatusUsage(s.workspaceID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow := &TaskWorkflow{Statuses: statuses}
+	remap := make(map[models.TaskStatus]models.TaskStatus)
+	var validationErrors ValidationErrors
+	for _, used := range usage {
+		if status, ok := workflow.Status(used.Status); ok {
+			if status.Category != used.StatusCategory {
+				validationErrors.AddError("statuses", fmt.Sprintf("status %q is used by tasks and must stay in category %s", used.Status, used.StatusCategory))
+			}
+			continue
+		}
+
+		if replacement, ok := workflow.First(used.StatusCategory); ok {
+			remap[used.Status] = replacement.Key
+		}
+	}
+
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+
+	return remap, nil
+}
+
+func (s *workflowService) workflowResponse(projectID *uint) (*WorkflowResponseDTO, error) {
+	workflow, err := loadTaskWorkflow(s.workflowRepo, s.workspaceID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := WorkflowToResponseDTO(workflow, projectID)
+	return &response, nil
+}
+
+func (s *workflowService) getOwnedProject(userID, projectID uint) (*models.Project, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	if project.UserID != userID {
+		return nil, ErrProjectNotFound
+	}
+
+	return project, nil
+}
\ No newline at end of file
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"task-api/models"
+	"time"
+)
+
+type CreateWorkspaceDTO struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+type UpdateWorkspaceDTO struct {
+	Name *string `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
+}
+
+type UpdateWorkspaceMemberDTO struct {
+	Role models.WorkspaceRole `json:"role" binding:"required,oneof=owner admin member guest"`
+}
+
+type InviteWorkspaceMemberDTO struct {
+	Email string               `json:"email" binding:"required,email"`
+	Role  models.WorkspaceRole `json:"role" binding:"required,oneof=admin member guest"`
+}
+
+type AcceptWorkspaceInvitationDTO struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type WorkspaceResponseDTO struct {
+	ID        uint                 `json:"id"`
+	Name      string               `json:"name"`
+	Personal  bool                 `json:"personal"`
+	OwnerID   uint                 `json:"owner_id"`
+	Role      models.WorkspaceRole `json:"role"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+type WorkspaceMemberResponseDTO struct {
+	UserID    uint                 `json:"user_id"`
+	Email     string               `json:"email"`
+	FirstName string               `json:"first_name"`
+	LastName  string               `json:"last_name"`
+	Role      models.WorkspaceRole `json:"role"`
+	JoinedAt  time.Time            `json:"joined_at"`
+}
+
+type WorkspaceInvitationResponseDTO struct {
+	ID          uint                 `json:"id"`
+	WorkspaceID uint                 `json:"workspace_id"`
+	Email       string               `json:"email"`
+	Role        models.WorkspaceRole `json:"role"`
+	InvitedByID uint                 `json:"invited_by_id"`
+	ExpiresAt   time.Time            `json:"expires_at"`
+	CreatedAt   time.Time            `json:"created_at"`
+}
+
+func WorkspaceToResponseDTO(workspace *models.Workspace, role models.WorkspaceRole) WorkspaceResponseDTO {
+	return WorkspaceResponseDTO{
+		ID:        workspace.ID,
+		Name:      workspace.Name,
+		Personal:  workspace.Personal,
+		OwnerID:   workspace.OwnerID,
+		Role:      role,
+		CreatedAt: workspace.CreatedAt,
+		UpdatedAt: workspace.UpdatedAt,
+	}
+}
+
+func WorkspaceMembersToResponseDTO(members []models.WorkspaceMember) []WorkspaceMemberResponseDTO {
+	result := make([]WorkspaceMemberResponseDTO, len(members))
+	for i, member := range members {
+		result[i] = WorkspaceMemberToResponseDTO(&member)
+	}
+	return result
+}
+
+func WorkspaceMemberToResponseDTO(member *models.WorkspaceMember) WorkspaceMemberResponseDTO {
+	return WorkspaceMemberResponseDTO{
+		UserID:    member.UserID,
+		Email:     member.User.Email,
+		FirstName: member.User.FirstName,
+		LastName:  member.User.LastName,
+		Role:      member.Role,
+		JoinedAt:  member.CreatedAt,
+	}
+}
+
+func WorkspaceInvitationToResponseDTO(invitation *models.WorkspaceInvitation) WorkspaceInvitationResponseDTO {
+	return WorkspaceInvitationResponseDTO{
+		ID:          invitation.ID,
+		WorkspaceID: invitation.WorkspaceID,
+		Email:       invitation.Email,
+		Role:        invitation.Role,
+		InvitedByID: invitation.InvitedByID,
+		ExpiresAt:   invitation.ExpiresAt,
+		CreatedAt:   invitation.CreatedAt,
+	}
+}
+
+func WorkspaceInvitationsToResponseDTO(invitations []models.WorkspaceInvitation) []WorkspaceInvitationResponseDTO {
+	result := make([]WorkspaceInvitationResponseDTO, len(invitations))
+	for i, invitation := range invitations {
+		result[i] = WorkspaceInvitationToResponseDTO(&invitation)
+	}
+	return result
+}
\ No newline at end of file
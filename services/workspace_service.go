@@ -0,0 +1,21 @@
+package services
+
+import (
+	"task-api/models"
+)
+
+type WorkspaceService interface {
+	ResolveWorkspace(userID, workspaceID uint) (*models.WorkspaceMember, error)
+	ListWorkspaces(userID uint) ([]WorkspaceResponseDTO, error)
+	CreateWorkspace(userID uint, dto CreateWorkspaceDTO) (*WorkspaceResponseDTO, error)
+	GetWorkspace(userID, workspaceID uint) (*WorkspaceResponseDTO, error)
+	UpdateWorkspace(userID, workspaceID uint, dto UpdateWorkspaceDTO) (*WorkspaceResponseDTO, error)
+	DeleteWorkspace(userID, workspaceID uint) error
+	ListMembers(userID, workspaceID uint) ([]WorkspaceMemberResponseDTO, error)
+	UpdateMember(userID, workspaceID, memberUserID uint, dto UpdateWorkspaceMemberDTO) (*WorkspaceMemberResponseDTO, error)
+	RemoveMember(userID, workspaceID, memberUserID uint) error
+	ListInvitations(userID, workspaceID uint) ([]WorkspaceInvitationResponseDTO, error)
+	InviteMember(userID, workspaceID uint, dto InviteWorkspaceMemberDTO) (*WorkspaceInvitationResponseDTO, error)
+	RevokeInvitation(userID, workspaceID, invitationID uint) error
+	AcceptInvitation(userID uint, dto AcceptWorkspaceInvitationDTO) (*WorkspaceResponseDTO, error)
+}
\ No newline at end of file
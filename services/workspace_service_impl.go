@@ -0,0 +1,442 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"task-api/mailer"
+	"task-api/models"
+	"task-api/repositories"
+	"task-api/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWorkspaceNotFound         = errors.New("workspace not found")
+	ErrWorkspaceMemberNotFound   = errors.New("workspace member not found")
+	ErrWorkspaceRoleInsufficient = errors.New("your workspace role does not allow this")
+	ErrPersonalWorkspace         = errors.New("personal workspaces cannot be deleted or left by their owner")
+	ErrLastWorkspaceOwner        = errors.New("a workspace needs at least one owner")
+	ErrAlreadyWorkspaceMember    = errors.New("user is already a member of this workspace")
+	ErrInvitationNotFound        = errors.New("invitation not found")
+	ErrInvitationExpired         = errors.New("invitation has expired")
+	ErrInvitationEmailMismatch   = errors.New("invitation was sent to a different email address")
+)
+
+const (
+	DefaultWorkspaceInvitationExpiry = 7 * 24 * time.Hour
+	personalWorkspaceName            = "Personal"
+)
+
+type workspaceService struct {
+	workspaceRepo  repositories.WorkspaceRepository
+	invitationRepo repositories.WorkspaceInvitationRepository
+	userRepo       repositories.UserRepository
+	mailer         mailer.Mailer
+}
+
+func NewWorkspaceService(workspaceRepo repositories.WorkspaceRepository, invitationRepo repositories.WorkspaceInvitationRepository, userRepo repositories.UserRepository, mail mailer.Mailer) WorkspaceService {
+	return &workspaceService{
+		workspaceRepo:  workspaceRepo,
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		mailer:         mail,
+	}
+}
+
+func (s *workspaceService) ResolveWorkspace(userID, workspaceID uint) (*models.WorkspaceMember, error) {
+	if workspaceID == 0 {
+		workspace, err := s.ensurePersonalWorkspace(userID)
+		if err != nil {
+			return nil, err
+		}
+		workspaceID = workspace.ID
+	}
+
+	membership, err := s.workspaceRepo.GetMember(workspaceID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return membership, err
+}
+
+func (s *workspaceService) ListWorkspaces(userID uint) ([]WorkspaceResponseDTO, error) {
+	if _, err := s.ensurePersonalWorkspace(userID); err != nil {
+		return nil, err
+	}
+
+	memberships, err := s.workspaceRepo.ListMemberships(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]WorkspaceResponseDTO, len(memberships))
+	for i, membership := range memberships {
+		result[i] = WorkspaceToResponseDTO(&membership.Workspace, membership.Role)
+	}
+	return result, nil
+}
+
+func (s *workspaceService) CreateWorkspace(userID uint, dto CreateWorkspaceDTO) (*WorkspaceResponseDTO, error) {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, NewValidationError("name", "name is required")
+	}
+
+	workspace := &models.Workspace{
+		Name:    name,
+		OwnerID: userID,
+	}
+	if err := s.workspaceRepo.Create(workspace); err != nil {
+		return nil, err
+	}
+
+	response := WorkspaceToResponseDTO(workspace, models.WorkspaceRoleOwner)
+	return &response, nil
+}
+
+func (s *workspaceService) GetWorkspace(userID, workspaceID uint) (*WorkspaceResponseDTO, error) {
+	membership, err := s.getMembership(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := WorkspaceToResponseDTO(&membership.Workspace, membership.Role)
+	return &response, nil
+}
+
+func (s *workspaceService) UpdateWorkspace(userID, workspaceID uint, dto UpdateWorkspaceDTO) (*WorkspaceResponseDTO, error) {
+	membership, err := s.getManagingMembership(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace := &membership.Workspace
+	if dto.Name != nil {
+		name := strings.TrimSpace(*dto.Name)
+		if name == "" {
+			return nil, NewValidationError("name", "name cannot be empty")
+		}
+		workspace.Name = name
+	}
+
+	if err := s.workspaceRepo.Update(workspace); err != nil {
+		return nil, err
+	}
+
+	response := WorkspaceToResponseDTO(workspace, membership.Role)
+	return &response, nil
+}
+
+func (s *workspaceService) DeleteWorkspace(userID, workspaceID uint) error {
+	membership, err := s.getMembership(userID, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if membership.Role != models.WorkspaceRoleOwner {
+		return ErrWorkspaceRoleInsufficient
+	}
+
+	if membership.Workspace.Personal {
+		return ErrPersonalWorkspace
+	}
+
+	return s.workspaceRepo.Delete(workspaceID, userID)
+}
+
+func (s *workspaceService) ListMembers(userID, workspaceID uint) ([]WorkspaceMemberResponseDTO, error) {
+	if _, err := s.getMembership(userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.workspaceRepo.ListMembers(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return WorkspaceMembersToResponseDTO(members), nil
+}
+
+func (s *workspaceService) UpdateMember(userID, workspaceID, memberUserID uint, dto UpdateWorkspaceMemberDTO) (*WorkspaceMemberResponseDTO, error) {
+	membership, err := s.getManagingMembership(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.getMember(workspaceID, memberUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.Role == dto.Role {
+		response := WorkspaceMemberToResponseDTO(member)
+		return &response, nil
+	}
+
+	if (member.Role == models.WorkspaceRoleOwner || dto.Role == models.WorkspaceRoleOwner) && membership.Role != models.WorkspaceRoleOwner {
+		return nil, ErrWorkspaceRoleInsufficient
+	}
+
+	if member.Role == models.WorkspaceRoleOwner {
+		if membership.Workspace.Personal && member.UserID == membership.Workspace.OwnerID {
+			return nil, ErrPersonalWorkspace
+		}
+		if err := s.checkRemainingOwners(workspaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	member.Role = dto.Role
+	if err := s.workspaceRepo.UpdateMember(member); err != nil {
+		return nil, err
+	}
+
+	response := WorkspaceMemberToResponseDTO(member)
+	return &response, nil
+}
+
+func (s *workspaceService) RemoveMember(userID, workspaceID, memberUserID uint) error {
+	membership, err := s.getMembership(userID, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	member := membership
+	if memberUserID != userID {
+		if !membership.Role.CanManageMembers() {
+			return ErrWorkspaceRoleInsufficient
+		}
+
+		member, err = s.getMember(workspaceID, memberUserID)
+		if err != nil {
+			return err
+		}
+
+		if member.Role == models.WorkspaceRoleOwner && membership.Role != models.WorkspaceRoleOwner {
+			return ErrWorkspaceRoleInsufficient
+		}
+	}
+
+	if membership.Workspace.Personal && member.UserID == membership.Workspace.OwnerID {
+		return ErrPersonalWorkspace
+	}
+
+	if member.Role == models.WorkspaceRoleOwner {
+		if err := s.checkRemainingOwners(workspaceID); err != nil {
+			return err
+		}
+	}
+
+	return s.workspaceRepo.RemoveMember(workspaceID, member.UserID, userID)
+}
+
+func (s *workspaceService) ListInvitations(userID, workspaceID uint) ([]WorkspaceInvitationResponseDTO, error) {
+	if _, err := s.getManagingMembership(userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.invitationRepo.ListPending(workspaceID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return WorkspaceInvitationsToResponseDTO(invitations), nil
+}
+
+func (s *workspaceService) InviteMember(userID, workspaceID uint, dto InviteWorkspaceMemberDTO) (*WorkspaceInvitationResponseDTO, error) {
+	membership, err := s.getManagingMembership(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	email := strings.TrimSpace(dto.Email)
+	if invitee, err := s.userRepo.GetByEmail(email); err == nil {
+		if _, err := s.workspaceRepo.GetMember(workspaceID, invitee.ID); err == nil {
+			return nil, ErrAlreadyWorkspaceMember
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	inviter, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := utils.GenerateSecureToken(utils.DefaultTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	expiry := utils.GetEnvMinutes("WORKSPACE_INVITATION_EXPIRY", DefaultWorkspaceInvitationExpiry)
+	invitation := &models.WorkspaceInvitation{
+		WorkspaceID: workspaceID,
+		Email:       email,
+		Role:        dto.Role,
+		TokenHash:   utils.HashToken(token),
+		InvitedByID: userID,
+		ExpiresAt:   time.Now().Add(expiry),
+	}
+	if err := s.invitationRepo.Create(invitation); err != nil {
+		return nil, err
+	}
+
+	msg := mailer.Message{
+		To:      email,
+		Subject: fmt.Sprintf("%s invited you to %s", inviter.FirstName, membership.Workspace.Name),
+		Body: fmt.Sprintf("Hi,\n\n%s %s invited you to join the workspace %q as %s. The invitation expires in %d hours.\n\n%s/accept-invitation?token=%s\n\nIf you were not expecting this invitation you can ignore this email.\n",
+			inviter.FirstName, inviter.LastName, membership.Workspace.Name, dto.Role, int(expiry.Hours()), utils.GetEnvWithDefault("APP_BASE_URL", "http://localhost:8080"), token),
+	}
+
+	if err := s.mailer.Send(msg); err != nil {
+		log.Printf("Failed to send invitation %d for workspace %d: %v", invitation.ID, workspaceID, err)
+	}
+
+	response := WorkspaceInvitationToResponseDTO(invitation)
+	return &response, nil
+}
+
+func (s *workspaceService) RevokeInvitation(userID, workspaceID, invitationID uint) error {
+	if _, err := s.getManagingMembership(userID, workspaceID); err != nil {
+		return err
+	}
+
+	invitation, err := s.invitationRepo.GetByID(invitationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvitationNotFound
+		}
+		return err
+	}
+
+	if invitation.WorkspaceID != workspaceID || invitation.AcceptedAt != nil {
+		return ErrInvitationNotFound
+	}
+
+	return s.invitationRepo.Delete(invitation.ID)
+}
+
+func (s *workspaceService) AcceptInvitation(userID uint, dto AcceptWorkspaceInvitationDTO) (*WorkspaceResponseDTO, error) {
+	invitation, err := s.invitationRepo.GetByTokenHash(utils.HashToken(strings.TrimSpace(dto.Token)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+
+	if invitation.AcceptedAt != nil {
+		return nil, ErrInvitationNotFound
+	}
+	if !invitation.IsPending() {
+		return nil, ErrInvitationExpired
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, ErrInvitationEmailMismatch
+	}
+
+	member := &models.WorkspaceMember{
+		WorkspaceID: invitation.WorkspaceID,
+		UserID:      userID,
+		Role:        invitation.Role,
+	}
+	if err := s.invitationRepo.Accept(invitation, member, time.Now()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+
+	return s.GetWorkspace(userID, invitation.WorkspaceID)
+}
+
+func (s *workspaceService) ensurePersonalWorkspace(userID uint) (*models.Workspace, error) {
+	workspace, err := s.workspaceRepo.GetPersonal(userID)
+	if err == nil {
+		return workspace, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	workspace = &models.Workspace{
+		Name:     personalWorkspaceName,
+		Personal: true,
+		OwnerID:  userID,
+	}
+	if err := s.workspaceRepo.Create(workspace); err != nil {
+		if existing, getErr := s.workspaceRepo.GetPersonal(userID); getErr == nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+
+	return workspace, nil
+}
+
+func (s *workspaceService) getMembership(userID, workspaceID uint) (*models.WorkspaceMember, error) {
+	membership, err := s.workspaceRepo.GetMember(workspaceID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (s *workspaceService) getManagingMembership(userID, workspaceID uint) (*models.WorkspaceMember, error) {
+	membership, err := s.getMembership(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !membership.Role.CanManageMembers() {
+		return nil, ErrWorkspaceRoleInsufficient
+	}
+
+	return membership, nil
+}
+
+func (s *workspaceService) getMember(workspaceID, userID uint) (*models.WorkspaceMember, error) {
+	member, err := s.workspaceRepo.GetMember(workspaceID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWorkspaceMemberNotFound
+		}
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	member.User = *user
+
+	return member, nil
+}
+
+func (s *workspaceService) checkRemainingOwners(workspaceID uint) error {
+	owners, err := s.workspaceRepo.CountMembersByRole(workspaceID, models.WorkspaceRoleOwner)
+	if err != nil {
+		return err
+	}
+
+	if owners <= 1 {
+		return ErrLastWorkspaceOwner
+	}
+
+	return nil
+}
\ No newline at end of file
@@ -76,11 +76,26 @@ resource "google_cloud_run_service" "task_api" {
           }
         }
 
+        env {
+          name = "MFA_ENCRYPTION_KEY"
+          value_from {
+            secret_key_ref {
+              name = google_secret_manager_secret_version.mfa_encryption_key.secret
+              key  = "latest"
+            }
+          }
+        }
+
         env {
           name  = "GIN_MODE"
           value = "release"
         }
 
+        env {
+          name  = "TRUSTED_PROXIES"
+          value = var.trusted_proxies
+        }
+
         startup_probe {
           initial_delay_seconds = 0
           timeout_seconds       = 240
@@ -110,7 +125,8 @@ resource "google_cloud_run_service" "task_api" {
     google_project_service.cloud_run,
     google_sql_database_instance.postgres,
     google_secret_manager_secret_version.db_password,
-    google_secret_manager_secret_version.jwt_secret
+    google_secret_manager_secret_version.jwt_secret,
+    google_secret_manager_secret_version.mfa_encryption_key
   ]
 }
 
@@ -45,4 +45,10 @@ resource "random_password" "db_password" {
 resource "random_password" "jwt_secret" {
   length  = 32
   special = false
+}
+
+# Generate random key for encrypting TOTP secrets
+resource "random_password" "mfa_encryption_key" {
+  length  = 48
+  special = false
 }
\ No newline at end of file
@@ -44,6 +44,12 @@ output "jwt_secret_name" {
   sensitive   = true
 }
 
+output "mfa_encryption_key_secret_name" {
+  description = "Secret Manager secret name for the TOTP secret encryption key"
+  value       = google_secret_manager_secret.mfa_encryption_key.secret_id
+  sensitive   = true
+}
+
 # Project Information
 output "project_id" {
   description = "The GCP project ID"
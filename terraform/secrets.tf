@@ -33,4 +33,20 @@ resource "google_secret_manager_secret" "jwt_secret" {
 resource "google_secret_manager_secret_version" "jwt_secret" {
   secret         = google_secret_manager_secret.jwt_secret.id
   secret_data_wo = random_password.jwt_secret.result
+}
+
+# Secret for the TOTP secret encryption key
+resource "google_secret_manager_secret" "mfa_encryption_key" {
+  secret_id = "mfa-encryption-key"
+  
+  replication {
+    auto {}
+  }
+
+  depends_on = [google_project_service.secret_manager]
+}
+
+resource "google_secret_manager_secret_version" "mfa_encryption_key" {
+  secret         = google_secret_manager_secret.mfa_encryption_key.id
+  secret_data_wo = random_password.mfa_encryption_key.result
 }
\ No newline at end of file
@@ -81,6 +81,12 @@ variable "min_instances" {
 }
 
 # Security Configuration
+variable "trusted_proxies" {
+  description = "Comma-separated IPs or CIDRs of proxies whose X-Forwarded-For header is trusted"
+  type        = string
+  default     = ""
+}
+
 variable "allow_unauthenticated" {
   description = "Allow unauthenticated access to Cloud Run service"
   type        = bool
@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	EncryptedSecretPrefix     = "enc:v1:"
+	minSecretEncryptionKeyLen = 32
+)
+
+var (
+	ErrMissingEncryptionKey = errors.New("MFA_ENCRYPTION_KEY not found")
+	ErrWeakEncryptionKey    = errors.New("MFA_ENCRYPTION_KEY must be at least 32 characters")
+	ErrInvalidCiphertext    = errors.New("invalid encrypted secret")
+)
+
+var (
+	secretCipher     cipher.AEAD
+	secretCipherErr  error
+	secretCipherOnce sync.Once
+)
+
+func InitSecretEncryption() error {
+	_, err := getSecretCipher()
+	return err
+}
+
+func getSecretCipher() (cipher.AEAD, error) {
+	secretCipherOnce.Do(func() {
+		secretCipher, secretCipherErr = newSecretCipher(os.Getenv("MFA_ENCRYPTION_KEY"))
+	})
+	return secretCipher, secretCipherErr
+}
+
+// newSecretCipher derives an AES-256-GCM key from the configured key, so any
+// sufficiently long random string can be used.
+func newSecretCipher(key string) (cipher.AEAD, error) {
+	if key == "" {
+		return nil, ErrMissingEncryptionKey
+	}
+	if len(key) < minSecretEncryptionKeyLen {
+		return nil, ErrWeakEncryptionKey
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func IsEncryptedSecret(value string) bool {
+	return strings.HasPrefix(value, EncryptedSecretPrefix)
+}
+
+func EncryptSecret(plaintext string) (string, error) {
+	aead, err := getSecretCipher()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return EncryptedSecretPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func DecryptSecret(value string) (string, error) {
+	aead, err := getSecretCipher()
+	if err != nil {
+		return "", err
+	}
+
+	if !IsEncryptedSecret(value) {
+		return "", ErrInvalidCiphertext
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(value, EncryptedSecretPrefix))
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+	return string(plaintext), nil
+}
\ No newline at end of file
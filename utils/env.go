@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func GetEnvWithDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func GetEnvMinutes(envKey string, defaultDuration time.Duration) time.Duration {
+	if envValue := os.Getenv(envKey); envValue != "" {
+		if minutes, err := strconv.Atoi(envValue); err == nil {
+			return time.Duration(minutes) * time.Minute
+		}
+	}
+	return defaultDuration
+}
+
+func GetEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
\ No newline at end of file
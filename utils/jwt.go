@@ -2,7 +2,6 @@ package utils
 
 import (
 	"errors"
-	"os"
 	"strconv"
 	"time"
 
@@ -14,6 +13,13 @@ type JWTClaims struct {
 	Email     string `json:"email"`
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
+	Role      string `json:"role"`
+	SessionID string `json:"sid,omitempty"`
+	jwt.RegisteredClaims
+}
+
+type MFAClaims struct {
+	Purpose string `json:"purpose"`
 	jwt.RegisteredClaims
 }
 
@@ -33,41 +39,34 @@ var (
 const (
 	DefaultAccessTokenExpiry  = 15 * time.Minute
 	DefaultRefreshTokenExpiry = 7 * 24 * time.Hour
+	DefaultMFATokenExpiry     = 5 * time.Minute
+	MFAPendingPurpose         = "mfa_pending"
 )
 
-func getJWTSecret() ([]byte, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return nil, ErrMissingSecretKey
-	}
-	return []byte(secret), nil
+func getTokenExpiry(envKey string, defaultDuration time.Duration) time.Duration {
+	return GetEnvMinutes(envKey, defaultDuration)
 }
 
-func getTokenExpiry(envKey string, defaultDuration time.Duration) time.Duration {
-	if envValue := os.Getenv(envKey); envValue != "" {
-		if minutes, err := strconv.Atoi(envValue); err == nil {
-			return time.Duration(minutes) * time.Minute
-		}
-	}
-	return defaultDuration
+func GetAccessTokenExpiry() time.Duration {
+	return getTokenExpiry("JWT_ACCESS_TOKEN_EXPIRY", DefaultAccessTokenExpiry)
 }
 
-func GenerateTokenPair(userID uint, email, firstName, lastName string) (*TokenPair, error) {
-	secret, err := getJWTSecret()
-	if err != nil {
-		return nil, err
-	}
+func GetRefreshTokenExpiry() time.Duration {
+	return getTokenExpiry("JWT_REFRESH_TOKEN_EXPIRY", DefaultRefreshTokenExpiry)
+}
 
-	accessTokenExpiry := getTokenExpiry("JWT_ACCESS_TOKEN_EXPIRY", DefaultAccessTokenExpiry)
-	refreshTokenExpiry := getTokenExpiry("JWT_REFRESH_TOKEN_EXPIRY", DefaultRefreshTokenExpiry)
+func GenerateAccessToken(userID uint, email, firstName, lastName, role, sessionID string) (string, time.Duration, error) {
+	accessTokenExpiry := GetAccessTokenExpiry()
 
 	now := time.Now()
-	
+
 	accessClaims := JWTClaims{
 		UserID:    userID,
 		Email:     email,
 		FirstName: firstName,
 		LastName:  lastName,
+		Role:      role,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenExpiry)),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -77,76 +76,78 @@ func GenerateTokenPair(userID uint, email, firstName, lastName string) (*TokenPa
 		},
 	}
 
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	accessTokenString, err := accessToken.SignedString(secret)
+	accessTokenString, err := signToken(accessClaims)
 	if err != nil {
-		return nil, err
+		return "", 0, err
 	}
 
-	refreshClaims := jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(now.Add(refreshTokenExpiry)),
-		IssuedAt:  jwt.NewNumericDate(now),
-		NotBefore: jwt.NewNumericDate(now),
-		Issuer:    "task-api",
-		Subject:   strconv.Itoa(int(userID)),
-	}
+	return accessTokenString, accessTokenExpiry, nil
+}
 
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	refreshTokenString, err := refreshToken.SignedString(secret)
+func ValidateAccessToken(tokenString string) (*JWTClaims, error) {
+	token, err := parseToken(tokenString, &JWTClaims{})
 	if err != nil {
 		return nil, err
 	}
 
-	return &TokenPair{
-		AccessToken:  accessTokenString,
-		RefreshToken: refreshTokenString,
-		ExpiresIn:    int64(accessTokenExpiry.Seconds()),
-	}, nil
+	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid && claims.UserID != 0 {
+		return claims, nil
+	}
+
+	return nil, ErrInvalidToken
 }
 
-func ValidateAccessToken(tokenString string) (*JWTClaims, error) {
-	secret, err := getJWTSecret()
-	if err != nil {
-		return nil, err
+func GenerateMFAToken(userID uint) (string, error) {
+	now := time.Now()
+
+	claims := MFAClaims{
+		Purpose: MFAPendingPurpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(getTokenExpiry("JWT_MFA_TOKEN_EXPIRY", DefaultMFATokenExpiry))),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "task-api",
+			Subject:   strconv.Itoa(int(userID)),
+		},
 	}
 
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrTokenMalformed
-		}
-		return secret, nil
-	})
+	return signToken(claims)
+}
 
+func ValidateMFAToken(tokenString string) (uint, error) {
+	token, err := parseToken(tokenString, &MFAClaims{})
 	if err != nil {
-		if errors.Is(err, jwt.ErrTokenExpired) {
-			return nil, ErrExpiredToken
-		}
-		if errors.Is(err, jwt.ErrTokenMalformed) {
-			return nil, ErrTokenMalformed
-		}
-		return nil, ErrInvalidToken
+		return 0, err
 	}
 
-	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
-		return claims, nil
+	claims, ok := token.Claims.(*MFAClaims)
+	if !ok || !token.Valid || claims.Purpose != MFAPendingPurpose {
+		return 0, ErrInvalidToken
 	}
 
-	return nil, ErrInvalidToken
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil || userID <= 0 {
+		return 0, ErrInvalidToken
+	}
+
+	return uint(userID), nil
 }
 
-func ValidateRefreshToken(tokenString string) (*jwt.RegisteredClaims, error) {
-	secret, err := getJWTSecret()
+func signToken(claims jwt.Claims) (string, error) {
+	keys, err := getKeySet()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	return keys.sign(claims)
+}
 
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrTokenMalformed
-		}
-		return secret, nil
-	})
+func parseToken(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	keys, err := getKeySet()
+	if err != nil {
+		return nil, err
+	}
 
+	token, err := jwt.ParseWithClaims(tokenString, claims, keys.keyFunc)
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
 			return nil, ErrExpiredToken
@@ -157,25 +158,7 @@ func ValidateRefreshToken(tokenString string) (*jwt.RegisteredClaims, error) {
 		return nil, ErrInvalidToken
 	}
 
-	if claims, ok := token.Claims.(*jwt.RegisteredClaims); ok && token.Valid {
-		return claims, nil
-	}
-
-	return nil, ErrInvalidToken
-}
-
-func RefreshAccessToken(refreshTokenString string, userID uint, email, firstName, lastName string) (*TokenPair, error) {
-	claims, err := ValidateRefreshToken(refreshTokenString)
-	if err != nil {
-		return nil, err
-	}
-
-	userIDFromToken, err := strconv.Atoi(claims.Subject)
-	if err != nil || uint(userIDFromToken) != userID {
-		return nil, ErrInvalidToken
-	}
-
-	return GenerateTokenPair(userID, email, firstName, lastName)
+	return token, nil
 }
 
 func ExtractUserIDFromToken(tokenString string) (uint, error) {
@@ -0,0 +1,298 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrUnsupportedKeyType = errors.New("unsupported key type")
+	ErrUnknownKeyID       = errors.New("unknown key id")
+)
+
+type verificationKey struct {
+	ID     string
+	Method jwt.SigningMethod
+	Public crypto.PublicKey
+}
+
+type KeySet struct {
+	signingKeyID     string
+	signingMethod    jwt.SigningMethod
+	signingKey       interface{}
+	verificationKeys map[string]verificationKey
+	keyOrder         []string
+	hmacSecret       []byte
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+var (
+	keySet     *KeySet
+	keySetErr  error
+	keySetOnce sync.Once
+)
+
+func InitKeys() error {
+	_, err := getKeySet()
+	return err
+}
+
+func getKeySet() (*KeySet, error) {
+	keySetOnce.Do(func() {
+		keySet, keySetErr = LoadKeySetFromEnv()
+	})
+	return keySet, keySetErr
+}
+
+func LoadKeySetFromEnv() (*KeySet, error) {
+	ks := &KeySet{
+		verificationKeys: make(map[string]verificationKey),
+	}
+
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		ks.hmacSecret = []byte(secret)
+	}
+
+	if path := os.Getenv("JWT_SIGNING_KEY_FILE"); path != "" {
+		privateKey, err := readPrivateKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load signing key: %w", err)
+		}
+
+		method, public, err := keyMaterial(privateKey)
+		if err != nil {
+			return nil, err
+		}
+
+		kid := os.Getenv("JWT_SIGNING_KEY_ID")
+		if kid == "" {
+			kid, err = keyThumbprint(public)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		ks.signingKeyID = kid
+		ks.signingMethod = method
+		ks.signingKey = privateKey
+		ks.addVerificationKey(kid, method, public)
+	}
+
+	if entries := os.Getenv("JWT_VERIFICATION_KEYS"); entries != "" {
+		for _, entry := range strings.Split(entries, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+
+			kid, path, found := strings.Cut(entry, "=")
+			if !found {
+				path = kid
+				kid = ""
+			}
+
+			public, err := readPublicKey(strings.TrimSpace(path))
+			if err != nil {
+				return nil, fmt.Errorf("failed to load verification key %s: %w", path, err)
+			}
+
+			method, err := publicKeyMethod(public)
+			if err != nil {
+				return nil, err
+			}
+
+			kid = strings.TrimSpace(kid)
+			if kid == "" {
+				kid, err = keyThumbprint(public)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			ks.addVerificationKey(kid, method, public)
+		}
+	}
+
+	if ks.signingKey == nil && ks.hmacSecret == nil {
+		return nil, ErrMissingSecretKey
+	}
+
+	return ks, nil
+}
+
+func (ks *KeySet) addVerificationKey(kid string, method jwt.SigningMethod, public crypto.PublicKey) {
+	if _, exists := ks.verificationKeys[kid]; !exists {
+		ks.keyOrder = append(ks.keyOrder, kid)
+	}
+	ks.verificationKeys[kid] = verificationKey{
+		ID:     kid,
+		Method: method,
+		Public: public,
+	}
+}
+
+func (ks *KeySet) sign(claims jwt.Claims) (string, error) {
+	if ks.signingKey == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(ks.hmacSecret)
+	}
+
+	token := jwt.NewWithClaims(ks.signingMethod, claims)
+	token.Header["kid"] = ks.signingKeyID
+	return token.SignedString(ks.signingKey)
+}
+
+func (ks *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok && ks.hmacSecret != nil {
+			return ks.hmacSecret, nil
+		}
+		return nil, ErrTokenMalformed
+	}
+
+	key, ok := ks.verificationKeys[kid]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, ErrTokenMalformed
+	}
+
+	return key.Public, nil
+}
+
+func (ks *KeySet) JWKS() (JWKSet, error) {
+	set := JWKSet{Keys: make([]JWK, 0, len(ks.keyOrder))}
+
+	for _, kid := range ks.keyOrder {
+		key := ks.verificationKeys[kid]
+		jwk := JWK{
+			Kid: kid,
+			Use: "sig",
+			Alg: key.Method.Alg(),
+		}
+
+		switch public := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			return JWKSet{}, ErrUnsupportedKeyType
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set, nil
+}
+
+func GetJWKS() (JWKSet, error) {
+	ks, err := getKeySet()
+	if err != nil {
+		return JWKSet{}, err
+	}
+	return ks.JWKS()
+}
+
+func readPEMBlock(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+	return block, nil
+}
+
+func readPrivateKey(path string) (interface{}, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return x509.ParsePKCS1PublicKey(block.Bytes)
+}
+
+func keyMaterial(privateKey interface{}) (jwt.SigningMethod, crypto.PublicKey, error) {
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		return jwt.SigningMethodRS256, &key.PublicKey, nil
+	case ed25519.PrivateKey:
+		return jwt.SigningMethodEdDSA, key.Public(), nil
+	default:
+		return nil, nil, ErrUnsupportedKeyType
+	}
+}
+
+func publicKeyMethod(public crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch public.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, ErrUnsupportedKeyType
+	}
+}
+
+func keyThumbprint(public crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return base64.RawURLEncoding.EncodeToString(sum[:12]), nil
+}
\ No newline at end of file
@@ -0,0 +1,404 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RecurrenceFrequency string
+
+const (
+	FrequencyDaily   RecurrenceFrequency = "DAILY"
+	FrequencyWeekly  RecurrenceFrequency = "WEEKLY"
+	FrequencyMonthly RecurrenceFrequency = "MONTHLY"
+	FrequencyYearly  RecurrenceFrequency = "YEARLY"
+)
+
+const (
+	maxRecurrencePeriods   = 1000
+	maxMonthlyByDayOrdinal = 5
+	maxYearlyByDayOrdinal  = 53
+)
+
+var ErrInvalidRecurrenceRule = errors.New("invalid recurrence rule")
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+type RecurrenceWeekday struct {
+	Weekday time.Weekday
+	Ordinal int
+}
+
+type RecurrenceRule struct {
+	Frequency  RecurrenceFrequency
+	Interval   int
+	ByDay      []RecurrenceWeekday
+	ByMonthDay []int
+	Count      int
+	Until      *time.Time
+}
+
+func ParseRecurrenceRule(value string, loc *time.Location) (*RecurrenceRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, recurrenceError("rule is empty")
+	}
+
+	rule := &RecurrenceRule{Interval: 1}
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		val = strings.ToUpper(strings.TrimSpace(val))
+		if !ok || key == "" || val == "" {
+			return nil, recurrenceError("malformed part %q", part)
+		}
+		if seen[key] {
+			return nil, recurrenceError("%s given more than once", key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			switch RecurrenceFrequency(val) {
+			case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+				rule.Frequency = RecurrenceFrequency(val)
+			default:
+				return nil, recurrenceError("unsupported FREQ %s", val)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, recurrenceError("INTERVAL must be a positive integer")
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, recurrenceError("COUNT must be a positive integer")
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseRecurrenceUntil(val, loc)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, item := range strings.Split(val, ",") {
+				weekday, err := parseRecurrenceWeekday(item)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, item := range strings.Split(val, ",") {
+				day, err := strconv.Atoi(item)
+				if err != nil || day == 0 || day < -31 || day > 31 {
+					return nil, recurrenceError("invalid BYMONTHDAY %s", item)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, day)
+			}
+		default:
+			return nil, recurrenceError("unsupported part %s", key)
+		}
+	}
+
+	if rule.Frequency == "" {
+		return nil, recurrenceError("FREQ is required")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, recurrenceError("COUNT and UNTIL cannot be combined")
+	}
+	if rule.Frequency == FrequencyWeekly && len(rule.ByMonthDay) > 0 {
+		return nil, recurrenceError("BYMONTHDAY cannot be used with FREQ=WEEKLY")
+	}
+	for _, weekday := range rule.ByDay {
+		if weekday.Ordinal != 0 && rule.Frequency != FrequencyMonthly && rule.Frequency != FrequencyYearly {
+			return nil, recurrenceError("numbered BYDAY values need FREQ=MONTHLY or FREQ=YEARLY")
+		}
+		if rule.Frequency == FrequencyMonthly && (weekday.Ordinal < -maxMonthlyByDayOrdinal || weekday.Ordinal > maxMonthlyByDayOrdinal) {
+			return nil, recurrenceError("numbered BYDAY values beyond %d need FREQ=YEARLY", maxMonthlyByDayOrdinal)
+		}
+	}
+
+	return rule, nil
+}
+
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, weekday := range r.ByDay {
+			days[i] = weekday.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (w RecurrenceWeekday) String() string {
+	for code, weekday := range rruleWeekdays {
+		if weekday == w.Weekday {
+			if w.Ordinal != 0 {
+				return strconv.Itoa(w.Ordinal) + code
+			}
+			return code
+		}
+	}
+	return ""
+}
+
+// NextOccurrence returns the occurrence after current, the index-th (from 0)
+// occurrence of the series, honouring COUNT as well as UNTIL.
+func (r *RecurrenceRule) NextOccurrence(current time.Time, index int, loc *time.Location) (time.Time, bool) {
+	if r.Count > 0 && index+1 >= r.Count {
+		return time.Time{}, false
+	}
+	return r.Next(current, loc)
+}
+
+// Next works in loc's wall-clock time so occurrences keep their time of day
+// across DST changes. COUNT is left to the caller.
+func (r *RecurrenceRule) Next(current time.Time, loc *time.Location) (time.Time, bool) {
+	local := current.In(loc)
+
+	var next time.Time
+	var ok bool
+	switch r.Frequency {
+	case FrequencyDaily:
+		next, ok = r.nextDaily(local)
+	case FrequencyWeekly:
+		next, ok = r.nextWeekly(local)
+	case FrequencyMonthly:
+		next, ok = r.nextMonthly(local)
+	case FrequencyYearly:
+		next, ok = r.nextYearly(local)
+	}
+
+	if !ok || (r.Until != nil && next.After(*r.Until)) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+func (r *RecurrenceRule) nextDaily(local time.Time) (time.Time, bool) {
+	for i := 1; i <= maxRecurrencePeriods; i++ {
+		candidate := atDay(local, local.Year(), local.Month(), local.Day()+i*r.Interval)
+		if r.matchesWeekday(candidate) && r.matchesMonthDay(candidate) {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *RecurrenceRule) nextWeekly(local time.Time) (time.Time, bool) {
+	if len(r.ByDay) == 0 {
+		return atDay(local, local.Year(), local.Month(), local.Day()+7*r.Interval), true
+	}
+
+	offsets := make([]int, 0, len(r.ByDay))
+	for _, weekday := range r.ByDay {
+		offsets = append(offsets, (int(weekday.Weekday)+6)%7)
+	}
+	sort.Ints(offsets)
+
+	weekStart := local.Day() - (int(local.Weekday())+6)%7
+	for period := 0; period <= maxRecurrencePeriods; period++ {
+		for _, offset := range offsets {
+			candidate := atDay(local, local.Year(), local.Month(), weekStart+period*7*r.Interval+offset)
+			if candidate.After(local) {
+				return candidate, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *RecurrenceRule) nextMonthly(local time.Time) (time.Time, bool) {
+	for period := 0; period <= maxRecurrencePeriods; period++ {
+		first := time.Date(local.Year(), local.Month()+time.Month(period*r.Interval), 1, 0, 0, 0, 0, time.UTC)
+		for _, date := range r.daysInMonth(first.Year(), first.Month(), local.Day()) {
+			candidate := atDay(local, date.Year(), date.Month(), date.Day())
+			if candidate.After(local) {
+				return candidate, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// nextYearly expands BYDAY and BYMONTHDAY across the whole year, as RFC 5545
+// does for yearly rules without BYMONTH, so numbered BYDAY values count
+// weekdays from the start or end of the year.
+func (r *RecurrenceRule) nextYearly(local time.Time) (time.Time, bool) {
+	for period := 0; period <= maxRecurrencePeriods; period++ {
+		for _, date := range r.daysInYear(local.Year()+period*r.Interval, local.Month(), local.Day()) {
+			candidate := atDay(local, date.Year(), date.Month(), date.Day())
+			if candidate.After(local) {
+				return candidate, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *RecurrenceRule) daysInMonth(year int, month time.Month, defaultDay int) []time.Time {
+	length := monthLength(year, month)
+
+	if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		if defaultDay > length {
+			return nil
+		}
+		return []time.Time{time.Date(year, month, defaultDay, 0, 0, 0, 0, time.UTC)}
+	}
+
+	var days []time.Time
+	for day := 1; day <= length; day++ {
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		if r.matchesMonthDay(date) && r.matchesNumberedWeekday(date, day, length) {
+			days = append(days, date)
+		}
+	}
+	return days
+}
+
+func (r *RecurrenceRule) daysInYear(year int, defaultMonth time.Month, defaultDay int) []time.Time {
+	if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		return r.daysInMonth(year, defaultMonth, defaultDay)
+	}
+
+	length := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+
+	var days []time.Time
+	for date := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); date.Year() == year; date = date.AddDate(0, 0, 1) {
+		if r.matchesMonthDay(date) && r.matchesNumberedWeekday(date, date.YearDay(), length) {
+			days = append(days, date)
+		}
+	}
+	return days
+}
+
+func (r *RecurrenceRule) matchesWeekday(date time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, weekday := range r.ByDay {
+		if weekday.Weekday == date.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesNumberedWeekday checks BYDAY for the day at position day (from 1) of
+// a month or year that is length days long.
+func (r *RecurrenceRule) matchesNumberedWeekday(date time.Time, day, length int) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, weekday := range r.ByDay {
+		if weekday.Weekday != date.Weekday() {
+			continue
+		}
+		switch {
+		case weekday.Ordinal == 0:
+			return true
+		case weekday.Ordinal > 0 && (day-1)/7+1 == weekday.Ordinal:
+			return true
+		case weekday.Ordinal < 0 && (length-day)/7+1 == -weekday.Ordinal:
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RecurrenceRule) matchesMonthDay(date time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	length := monthLength(date.Year(), date.Month())
+	for _, day := range r.ByMonthDay {
+		if day == date.Day() || (day < 0 && length+day+1 == date.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func monthLength(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func atDay(local time.Time, year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, local.Hour(), local.Minute(), local.Second(), 0, local.Location())
+}
+
+func parseRecurrenceWeekday(value string) (RecurrenceWeekday, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 2 {
+		return RecurrenceWeekday{}, recurrenceError("invalid BYDAY %s", value)
+	}
+
+	weekday, ok := rruleWeekdays[value[len(value)-2:]]
+	if !ok {
+		return RecurrenceWeekday{}, recurrenceError("invalid BYDAY %s", value)
+	}
+
+	result := RecurrenceWeekday{Weekday: weekday}
+	if prefix := value[:len(value)-2]; prefix != "" {
+		ordinal, err := strconv.Atoi(prefix)
+		if err != nil || ordinal == 0 || ordinal < -maxYearlyByDayOrdinal || ordinal > maxYearlyByDayOrdinal {
+			return RecurrenceWeekday{}, recurrenceError("invalid BYDAY %s", value)
+		}
+		result.Ordinal = ordinal
+	}
+	return result, nil
+}
+
+func parseRecurrenceUntil(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102T150405", value, loc); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102", value, loc); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Second), nil
+	}
+	return time.Time{}, recurrenceError("invalid UNTIL %s", value)
+}
+
+func recurrenceError(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidRecurrenceRule, fmt.Sprintf(format, args...))
+}
\ No newline at end of file
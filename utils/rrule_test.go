@@ -0,0 +1,265 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s not available: %v", name, err)
+	}
+	return loc
+}
+
+func TestRecurrenceRuleNext(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start string
+		want  []string
+	}{
+		{
+			name:  "weekly interval with BYDAY",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			start: "2025-01-06T09:00:00Z",
+			want:  []string{"2025-01-09T09:00:00Z", "2025-01-20T09:00:00Z", "2025-01-23T09:00:00Z", "2025-02-03T09:00:00Z"},
+		},
+		{
+			name:  "weekly interval with BYDAY starting mid-week",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			start: "2025-01-09T09:00:00Z",
+			want:  []string{"2025-01-20T09:00:00Z", "2025-01-23T09:00:00Z"},
+		},
+		{
+			name:  "weekly interval without BYDAY",
+			rule:  "FREQ=WEEKLY;INTERVAL=2",
+			start: "2025-01-06T09:00:00Z",
+			want:  []string{"2025-01-20T09:00:00Z", "2025-02-03T09:00:00Z"},
+		},
+		{
+			name:  "BYMONTHDAY=31 skips short months",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=31",
+			start: "2025-01-31T09:00:00Z",
+			want:  []string{"2025-03-31T09:00:00Z", "2025-05-31T09:00:00Z", "2025-07-31T09:00:00Z", "2025-08-31T09:00:00Z"},
+		},
+		{
+			name:  "BYMONTHDAY=-1 is the last day of each month",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: "2025-01-31T09:00:00Z",
+			want:  []string{"2025-02-28T09:00:00Z", "2025-03-31T09:00:00Z", "2025-04-30T09:00:00Z"},
+		},
+		{
+			name:  "BYMONTHDAY=-1 in a leap year",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: "2024-01-31T09:00:00Z",
+			want:  []string{"2024-02-29T09:00:00Z", "2024-03-31T09:00:00Z"},
+		},
+		{
+			name:  "monthly on the 31st without BYMONTHDAY skips short months",
+			rule:  "FREQ=MONTHLY",
+			start: "2025-01-31T09:00:00Z",
+			want:  []string{"2025-03-31T09:00:00Z", "2025-05-31T09:00:00Z"},
+		},
+		{
+			name:  "monthly last Friday",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR",
+			start: "2025-01-31T09:00:00Z",
+			want:  []string{"2025-02-28T09:00:00Z", "2025-03-28T09:00:00Z"},
+		},
+		{
+			name:  "yearly on February 29 waits for leap years",
+			rule:  "FREQ=YEARLY",
+			start: "2024-02-29T09:00:00Z",
+			want:  []string{"2028-02-29T09:00:00Z"},
+		},
+		{
+			name:  "yearly BYDAY is every matching weekday of the year",
+			rule:  "FREQ=YEARLY;BYDAY=MO",
+			start: "2025-12-22T09:00:00Z",
+			want:  []string{"2025-12-29T09:00:00Z", "2026-01-05T09:00:00Z", "2026-01-12T09:00:00Z"},
+		},
+		{
+			name:  "yearly numbered BYDAY counts from the start of the year",
+			rule:  "FREQ=YEARLY;BYDAY=20MO",
+			start: "2025-01-01T09:00:00Z",
+			want:  []string{"2025-05-19T09:00:00Z", "2026-05-18T09:00:00Z"},
+		},
+		{
+			name:  "yearly numbered BYDAY counts from the end of the year",
+			rule:  "FREQ=YEARLY;BYDAY=-1FR",
+			start: "2025-01-01T09:00:00Z",
+			want:  []string{"2025-12-26T09:00:00Z"},
+		},
+		{
+			name:  "yearly 53rd weekday only exists in some years",
+			rule:  "FREQ=YEARLY;BYDAY=53WE",
+			start: "2025-01-01T09:00:00Z",
+			want:  []string{"2025-12-31T09:00:00Z", "2031-12-31T09:00:00Z"},
+		},
+		{
+			name:  "yearly BYMONTHDAY applies to every month",
+			rule:  "FREQ=YEARLY;BYMONTHDAY=15",
+			start: "2025-01-15T09:00:00Z",
+			want:  []string{"2025-02-15T09:00:00Z", "2025-03-15T09:00:00Z"},
+		},
+		{
+			name:  "UNTIL is inclusive",
+			rule:  "FREQ=DAILY;UNTIL=20250103T090000Z",
+			start: "2025-01-01T09:00:00Z",
+			want:  []string{"2025-01-02T09:00:00Z", "2025-01-03T09:00:00Z"},
+		},
+		{
+			name:  "date-only UNTIL covers the whole day",
+			rule:  "FREQ=DAILY;UNTIL=20250103",
+			start: "2025-01-02T18:00:00Z",
+			want:  []string{"2025-01-03T18:00:00Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.rule, time.UTC)
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule(%q): %v", tt.rule, err)
+			}
+
+			current, _ := time.Parse(time.RFC3339, tt.start)
+			for _, wantValue := range tt.want {
+				want, _ := time.Parse(time.RFC3339, wantValue)
+				next, ok := rule.Next(current, time.UTC)
+				if !ok || !next.Equal(want) {
+					t.Fatalf("Next(%s) = (%s, %v), want %s", current.Format(time.RFC3339), next.Format(time.RFC3339), ok, wantValue)
+				}
+				current = next
+			}
+
+			if rule.Until != nil {
+				if next, ok := rule.Next(current, time.UTC); ok {
+					t.Errorf("Next(%s) = %s, want no occurrence after UNTIL", current.Format(time.RFC3339), next.Format(time.RFC3339))
+				}
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleNextKeepsWallClockAcrossDST(t *testing.T) {
+	loc := mustLoadLocation(t, "America/New_York")
+
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		want  time.Time
+	}{
+		{
+			name:  "daily across spring forward",
+			rule:  "FREQ=DAILY",
+			start: time.Date(2025, time.March, 8, 9, 0, 0, 0, loc),
+			want:  time.Date(2025, time.March, 9, 9, 0, 0, 0, loc),
+		},
+		{
+			name:  "weekly across fall back",
+			rule:  "FREQ=WEEKLY;BYDAY=SA",
+			start: time.Date(2025, time.November, 1, 9, 0, 0, 0, loc),
+			want:  time.Date(2025, time.November, 8, 9, 0, 0, 0, loc),
+		},
+		{
+			name:  "monthly across spring forward",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=1",
+			start: time.Date(2025, time.March, 1, 9, 0, 0, 0, loc),
+			want:  time.Date(2025, time.April, 1, 9, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.rule, loc)
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule(%q): %v", tt.rule, err)
+			}
+
+			next, ok := rule.Next(tt.start.UTC(), loc)
+			if !ok || !next.Equal(tt.want) {
+				t.Fatalf("Next = (%s, %v), want %s", next, ok, tt.want)
+			}
+			if next.In(loc).Hour() != 9 {
+				t.Errorf("Next = %s, want 09:00 local time", next.In(loc))
+			}
+		})
+	}
+
+	spring, _ := ParseRecurrenceRule("FREQ=DAILY", loc)
+	next, _ := spring.Next(time.Date(2025, time.March, 8, 9, 0, 0, 0, loc), loc)
+	if gap := next.Sub(time.Date(2025, time.March, 8, 9, 0, 0, 0, loc)); gap != 23*time.Hour {
+		t.Errorf("daily occurrence across spring forward is %s apart, want 23h", gap)
+	}
+}
+
+func TestRecurrenceRuleNextOccurrenceHonoursCount(t *testing.T) {
+	rule, err := ParseRecurrenceRule("FREQ=DAILY;COUNT=3", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current := time.Date(2025, time.January, 1, 9, 0, 0, 0, time.UTC)
+	for index := 0; index < 2; index++ {
+		next, ok := rule.NextOccurrence(current, index, time.UTC)
+		if !ok {
+			t.Fatalf("NextOccurrence after index %d ended the series, want occurrence %d of 3", index, index+2)
+		}
+		current = next
+	}
+
+	if next, ok := rule.NextOccurrence(current, 2, time.UTC); ok {
+		t.Errorf("NextOccurrence after the third occurrence = %s, want none", next)
+	}
+	if want := time.Date(2025, time.January, 3, 9, 0, 0, 0, time.UTC); !current.Equal(want) {
+		t.Errorf("third occurrence = %s, want %s", current, want)
+	}
+}
+
+func TestParseRecurrenceRule(t *testing.T) {
+	tests := []struct {
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{rule: "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH"},
+		{rule: "freq=monthly;byday=-1fr", want: "FREQ=MONTHLY;BYDAY=-1FR"},
+		{rule: "FREQ=YEARLY;BYDAY=20MO", want: "FREQ=YEARLY;BYDAY=20MO"},
+		{rule: "FREQ=YEARLY;BYDAY=-53SU", want: "FREQ=YEARLY;BYDAY=-53SU"},
+		{rule: "FREQ=DAILY;COUNT=5", want: "FREQ=DAILY;COUNT=5"},
+		{rule: "FREQ=MONTHLY;BYDAY=6MO", wantErr: true},
+		{rule: "FREQ=YEARLY;BYDAY=54MO", wantErr: true},
+		{rule: "FREQ=WEEKLY;BYDAY=1MO", wantErr: true},
+		{rule: "FREQ=WEEKLY;BYMONTHDAY=1", wantErr: true},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		{rule: "FREQ=DAILY;COUNT=2;UNTIL=20250101", wantErr: true},
+		{rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{rule: "FREQ=HOURLY", wantErr: true},
+		{rule: "INTERVAL=2", wantErr: true},
+		{rule: "FREQ=DAILY;FREQ=WEEKLY", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.rule, time.UTC)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRecurrenceRule) {
+					t.Fatalf("ParseRecurrenceRule(%q) error = %v, want ErrInvalidRecurrenceRule", tt.rule, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule(%q): %v", tt.rule, err)
+			}
+			if got := rule.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
\ No newline at end of file
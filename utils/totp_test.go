@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 seed from RFC 6238 Appendix B.
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+// The RFC lists 8-digit codes; with TOTPDigits = 6 the expected codes are
+// their last six digits.
+func TestGenerateTOTPCodeRFC6238Vectors(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		got, err := GenerateTOTPCode(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("GenerateTOTPCode at %d: %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("GenerateTOTPCode at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTPCode(t *testing.T) {
+	at := time.Unix(1111111111, 0)
+	step := TOTPStep(at)
+
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{"current step", "050471", step, true},
+		{"surrounding whitespace", " 050471 ", step, true},
+		{"previous step within skew", mustTOTPCode(t, step-1), step - 1, true},
+		{"next step within skew", mustTOTPCode(t, step+1), step + 1, true},
+		{"outside skew", mustTOTPCode(t, step-2), 0, false},
+		{"wrong code", "000000", 0, false},
+		{"wrong length", "05047", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, gotOK := ValidateTOTPCode(rfc6238Secret, tt.code, at)
+			if gotOK != tt.wantOK || gotStep != tt.wantStep {
+				t.Errorf("ValidateTOTPCode(%q) = (%d, %v), want (%d, %v)", tt.code, gotStep, gotOK, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerateTOTPCodeAcceptsLowercaseSecret(t *testing.T) {
+	lower := []byte(rfc6238Secret)
+	for i, c := range lower {
+		if c >= 'A' && c <= 'Z' {
+			lower[i] = c + 'a' - 'A'
+		}
+	}
+
+	got, err := GenerateTOTPCode(string(lower), TOTPStep(time.Unix(59, 0)))
+	if err != nil || got != "287082" {
+		t.Errorf("GenerateTOTPCode with lowercase secret = (%s, %v), want 287082", got, err)
+	}
+}
+
+func mustTOTPCode(t *testing.T, step int64) string {
+	t.Helper()
+	code, err := GenerateTOTPCode(rfc6238Secret, step)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
\ No newline at end of file